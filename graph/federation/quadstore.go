@@ -0,0 +1,336 @@
+// Package federation implements a read-only QuadStore resolving queries against the union of several QuadStores.
+package federation
+
+import (
+	"context"
+	"errors"
+
+	"github.com/cayleygraph/cayley/graph"
+	"github.com/cayleygraph/cayley/graph/iterator"
+	"github.com/cayleygraph/cayley/graph/refs"
+	"github.com/cayleygraph/quad"
+)
+
+var errReadOnly = errors.New("federated quad store is read-only")
+
+var _ graph.QuadStore = (*QuadStore)(nil)
+
+// QuadStore is a read-only QuadStore resolving queries against the union of several QuadStores.
+// Nodes are referenced by their values so a traversal can continue from one store to another.
+//
+// Sizes are the sums of the sizes reported by the stores, so Stats and QuadIteratorSize never
+// report them as exact, even if the stores do. Iterating removes the results found in several
+// stores by remembering all the results returned so far, which uses memory growing with the
+// number of results.
+type QuadStore struct {
+	stores []graph.QuadStore
+}
+
+// New returns a read-only QuadStore holding the union of the quads of stores.
+// Closing it does not close the underlying stores.
+func New(stores ...graph.QuadStore) *QuadStore {
+	return &QuadStore{stores: stores}
+}
+
+// federatedQuad is a reference to a quad of any of the federated stores.
+type federatedQuad quad.Quad
+
+func (q federatedQuad) Key() interface{} { return q }
+
+// ValueOf implements graph.QuadStore.
+func (f *QuadStore) ValueOf(v quad.Value) graph.Ref {
+	if v == nil {
+		return nil
+	}
+	for _, qs := range f.stores {
+		if qs.ValueOf(v) != nil {
+			return refs.PreFetched(v)
+		}
+	}
+	return nil
+}
+
+// NameOf implements graph.QuadStore.
+func (f *QuadStore) NameOf(ref graph.Ref) quad.Value {
+	if v, ok := ref.(refs.PreFetchedValue); ok {
+		return v.NameOf()
+	}
+	return nil
+}
+
+// Quad implements graph.QuadStore.
+func (f *QuadStore) Quad(ref graph.Ref) quad.Quad {
+	q, _ := ref.(federatedQuad)
+	return quad.Quad(q)
+}
+
+// QuadIterator implements graph.QuadStore.
+func (f *QuadStore) QuadIterator(d quad.Direction, ref graph.Ref) iterator.Shape {
+	name := f.NameOf(ref)
+	if name == nil {
+		return iterator.NewNull()
+	}
+	var subs []storeShape
+	for _, qs := range f.stores {
+		if r := qs.ValueOf(name); r != nil {
+			subs = append(subs, storeShape{qs: qs, shape: qs.QuadIterator(d, r)})
+		}
+	}
+	return &federatedShape{
+		name:    "FederatedQuads",
+		subs:    subs,
+		convert: quadOf,
+		contains: func(ref refs.Ref) bool {
+			q, ok := ref.(federatedQuad)
+			if !ok {
+				return false
+			}
+			v := quad.Quad(q).Get(d)
+			return v != nil && v.String() == name.String()
+		},
+	}
+}
+
+// QuadIteratorSize implements graph.QuadStore.
+// Quads present in several stores are counted once per store, the result is never exact.
+func (f *QuadStore) QuadIteratorSize(ctx context.Context, d quad.Direction, ref graph.Ref) (refs.Size, error) {
+	name := f.NameOf(ref)
+	var size refs.Size
+	if name == nil {
+		return size, nil
+	}
+	for _, qs := range f.stores {
+		r := qs.ValueOf(name)
+		if r == nil {
+			continue
+		}
+		sz, err := qs.QuadIteratorSize(ctx, d, r)
+		if err != nil {
+			return size, err
+		}
+		size.Value += sz.Value
+	}
+	return size, nil
+}
+
+// QuadDirection implements graph.QuadStore.
+func (f *QuadStore) QuadDirection(ref graph.Ref, d quad.Direction) graph.Ref {
+	v := f.Quad(ref).Get(d)
+	if v == nil {
+		return nil
+	}
+	return refs.PreFetched(v)
+}
+
+// Stats implements graph.QuadStore.
+// Quads and nodes present in several stores are counted once per store, the result is never exact.
+func (f *QuadStore) Stats(ctx context.Context, exact bool) (graph.Stats, error) {
+	var st graph.Stats
+	for _, qs := range f.stores {
+		sst, err := qs.Stats(ctx, exact)
+		if err != nil {
+			return st, err
+		}
+		st.Nodes.Value += sst.Nodes.Value
+		st.Quads.Value += sst.Quads.Value
+	}
+	return st, nil
+}
+
+// ApplyDeltas implements graph.QuadStore.
+func (f *QuadStore) ApplyDeltas(in []graph.Delta, opts graph.IgnoreOpts) error {
+	return errReadOnly
+}
+
+// NewQuadWriter implements graph.QuadStore.
+func (f *QuadStore) NewQuadWriter() (quad.WriteCloser, error) {
+	return nil, errReadOnly
+}
+
+// NodesAllIterator implements graph.QuadStore.
+func (f *QuadStore) NodesAllIterator() iterator.Shape {
+	subs := make([]storeShape, 0, len(f.stores))
+	for _, qs := range f.stores {
+		subs = append(subs, storeShape{qs: qs, shape: qs.NodesAllIterator()})
+	}
+	return &federatedShape{
+		name: "FederatedNodes",
+		subs: subs,
+		convert: func(qs graph.QuadStore, ref refs.Ref) refs.Ref {
+			if v := qs.NameOf(ref); v != nil {
+				return refs.PreFetched(v)
+			}
+			return nil
+		},
+		contains: func(ref refs.Ref) bool {
+			return f.ValueOf(f.NameOf(ref)) != nil
+		},
+	}
+}
+
+// QuadsAllIterator implements graph.QuadStore.
+func (f *QuadStore) QuadsAllIterator() iterator.Shape {
+	subs := make([]storeShape, 0, len(f.stores))
+	for _, qs := range f.stores {
+		subs = append(subs, storeShape{qs: qs, shape: qs.QuadsAllIterator()})
+	}
+	return &federatedShape{
+		name:    "FederatedQuads",
+		subs:    subs,
+		convert: quadOf,
+		contains: func(ref refs.Ref) bool {
+			_, ok := ref.(federatedQuad)
+			return ok
+		},
+	}
+}
+
+// Close implements graph.QuadStore.
+func (f *QuadStore) Close() error {
+	return nil
+}
+
+// quadOf converts the reference to a quad of qs to a federated one.
+func quadOf(qs graph.QuadStore, ref refs.Ref) refs.Ref {
+	return federatedQuad(qs.Quad(ref))
+}
+
+// storeShape is an iterator of one of the federated stores.
+type storeShape struct {
+	qs    graph.QuadStore
+	shape iterator.Shape
+}
+
+var _ iterator.Shape = (*federatedShape)(nil)
+
+// federatedShape is an iterator of the distinct results of iterators of several stores.
+// Lookups assume the references were produced by the federation and only check them with contains.
+type federatedShape struct {
+	name     string
+	subs     []storeShape
+	convert  func(qs graph.QuadStore, ref refs.Ref) refs.Ref
+	contains func(ref refs.Ref) bool
+}
+
+func (it *federatedShape) Iterate() iterator.Scanner {
+	return &federatedNext{shape: it, seen: make(map[interface{}]struct{})}
+}
+
+func (it *federatedShape) Lookup() iterator.Index {
+	return &federatedContains{shape: it}
+}
+
+func (it *federatedShape) Stats(ctx context.Context) (iterator.Costs, error) {
+	var costs iterator.Costs
+	for _, sub := range it.subs {
+		st, err := sub.shape.Stats(ctx)
+		if err != nil {
+			return costs, err
+		}
+		costs.Size.Value += st.Size.Value
+		if st.NextCost > costs.NextCost {
+			costs.NextCost = st.NextCost
+		}
+	}
+	costs.NextCost++
+	costs.ContainsCost = 1
+	return costs, nil
+}
+
+func (it *federatedShape) Optimize(ctx context.Context) (iterator.Shape, bool) {
+	for i, sub := range it.subs {
+		if nsub, ok := sub.shape.Optimize(ctx); ok {
+			it.subs[i].shape = nsub
+		}
+	}
+	return it, false
+}
+
+func (it *federatedShape) SubIterators() []iterator.Shape {
+	subs := make([]iterator.Shape, 0, len(it.subs))
+	for _, sub := range it.subs {
+		subs = append(subs, sub.shape)
+	}
+	return subs
+}
+
+func (it *federatedShape) String() string {
+	return it.name
+}
+
+type federatedNext struct {
+	shape *federatedShape
+	index int
+	cur   iterator.Scanner
+	// seen are the keys of all the results returned so far, it is not bounded
+	seen   map[interface{}]struct{}
+	result refs.Ref
+	err    error
+}
+
+func (it *federatedNext) Next(ctx context.Context) bool {
+	for it.err == nil {
+		if it.cur == nil {
+			if it.index >= len(it.shape.subs) {
+				return false
+			}
+			it.cur = it.shape.subs[it.index].shape.Iterate()
+			it.index++
+		}
+		if !it.cur.Next(ctx) {
+			it.err = it.cur.Err()
+			if err := it.cur.Close(); it.err == nil {
+				it.err = err
+			}
+			it.cur = nil
+			continue
+		}
+		ref := it.shape.convert(it.shape.subs[it.index-1].qs, it.cur.Result())
+		if ref == nil {
+			continue
+		}
+		key := refs.ToKey(ref)
+		if _, ok := it.seen[key]; ok {
+			continue
+		}
+		it.seen[key] = struct{}{}
+		it.result = ref
+		return true
+	}
+	return false
+}
+
+func (it *federatedNext) NextPath(ctx context.Context) bool  { return false }
+func (it *federatedNext) TagResults(dst map[string]refs.Ref) {}
+func (it *federatedNext) Result() refs.Ref                   { return it.result }
+func (it *federatedNext) Err() error                         { return it.err }
+func (it *federatedNext) String() string                     { return it.shape.name + "Next" }
+
+func (it *federatedNext) Close() error {
+	if it.cur == nil {
+		return nil
+	}
+	err := it.cur.Close()
+	it.cur = nil
+	return err
+}
+
+type federatedContains struct {
+	shape  *federatedShape
+	result refs.Ref
+}
+
+func (it *federatedContains) Contains(ctx context.Context, ref refs.Ref) bool {
+	if ref == nil || !it.shape.contains(ref) {
+		return false
+	}
+	it.result = ref
+	return true
+}
+
+func (it *federatedContains) NextPath(ctx context.Context) bool  { return false }
+func (it *federatedContains) TagResults(dst map[string]refs.Ref) {}
+func (it *federatedContains) Result() refs.Ref                   { return it.result }
+func (it *federatedContains) Err() error                         { return nil }
+func (it *federatedContains) Close() error                       { return nil }
+func (it *federatedContains) String() string                     { return it.shape.name + "Contains" }
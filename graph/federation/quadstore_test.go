@@ -0,0 +1,70 @@
+package federation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cayleygraph/cayley/graph"
+	"github.com/cayleygraph/cayley/graph/memstore"
+	"github.com/cayleygraph/quad"
+)
+
+func newFederation() *QuadStore {
+	return New(
+		memstore.New(
+			quad.MakeIRI("alice", "likes", "bob", ""),
+		),
+		memstore.New(
+			quad.MakeIRI("bob", "likes", "dan", ""),
+			quad.MakeIRI("alice", "likes", "bob", ""),
+		),
+	)
+}
+
+func TestQuadsAll(t *testing.T) {
+	qs := newFederation()
+	ctx := context.TODO()
+	it := qs.QuadsAllIterator().Iterate()
+	defer it.Close()
+	var quads []quad.Quad
+	for it.Next(ctx) {
+		quads = append(quads, qs.Quad(it.Result()))
+	}
+	require.NoError(t, it.Err())
+	// alice likes bob in both stores, the quad is only returned once.
+	require.Equal(t, []quad.Quad{
+		quad.MakeIRI("alice", "likes", "bob", ""),
+		quad.MakeIRI("bob", "likes", "dan", ""),
+	}, quads)
+}
+
+func TestQuadIterator(t *testing.T) {
+	qs := newFederation()
+	ctx := context.TODO()
+	it := graph.NewHasA(qs, qs.QuadIterator(quad.Subject, qs.ValueOf(quad.IRI("bob"))), quad.Object).Iterate()
+	defer it.Close()
+	var objects []quad.Value
+	for it.Next(ctx) {
+		objects = append(objects, qs.NameOf(it.Result()))
+	}
+	require.NoError(t, it.Err())
+	require.Equal(t, []quad.Value{quad.IRI("dan")}, objects)
+}
+
+func TestStatsNotExact(t *testing.T) {
+	qs := newFederation()
+	st, err := qs.Stats(context.TODO(), true)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), st.Quads.Value)
+	require.False(t, st.Quads.Exact)
+	require.False(t, st.Nodes.Exact)
+}
+
+func TestReadOnly(t *testing.T) {
+	qs := New(memstore.New())
+	_, err := qs.NewQuadWriter()
+	require.Error(t, err)
+	require.Error(t, qs.ApplyDeltas(nil, graph.IgnoreOpts{}))
+}
@@ -5,23 +5,40 @@ import (
 	"sort"
 
 	"github.com/cayleygraph/cayley/graph/refs"
+	"github.com/cayleygraph/quad"
 )
 
+// Less reports whether value a should be ordered before value b.
+type Less func(a, b quad.Value) bool
+
 // Sort iterator orders values from it's subiterator.
 type Sort struct {
 	namer refs.Namer
 	subIt Shape
+	less  Less
+	desc  bool
 }
 
 // NewSort creates a new Sort iterator.
 // TODO(dennwc): This iterator must not be used inside And: it may be moved to a Contains branch and won't do anything.
 //               We should make And/Intersect account for this.
 func NewSort(namer refs.Namer, subIt Shape) *Sort {
-	return &Sort{namer, subIt}
+	return &Sort{namer: namer, subIt: subIt}
+}
+
+// NewSortDescending creates a new Sort iterator that orders values in descending order.
+func NewSortDescending(namer refs.Namer, subIt Shape) *Sort {
+	return &Sort{namer: namer, subIt: subIt, desc: true}
+}
+
+// NewSortWith creates a new Sort iterator that orders values using the provided comparator.
+// Values that are equal according to less keep the order of the subiterator.
+func NewSortWith(namer refs.Namer, subIt Shape, less Less, desc bool) *Sort {
+	return &Sort{namer: namer, subIt: subIt, less: less, desc: desc}
 }
 
 func (it *Sort) Iterate() Scanner {
-	return newSortNext(it.namer, it.subIt.Iterate())
+	return newSortNext(it.namer, it.subIt.Iterate(), it.less, it.desc)
 }
 
 func (it *Sort) Lookup() Index {
@@ -53,6 +70,9 @@ func (it *Sort) Stats(ctx context.Context) (Costs, error) {
 }
 
 func (it *Sort) String() string {
+	if it.desc {
+		return "SortDescending"
+	}
 	return "Sort"
 }
 
@@ -63,6 +83,7 @@ func (it *Sort) SubIterators() []Shape {
 
 type sortValue struct {
 	result
+	name  quad.Value
 	str   string
 	paths []result
 }
@@ -74,9 +95,20 @@ func (v sortByString) Less(i, j int) bool {
 }
 func (v sortByString) Swap(i, j int) { v[i], v[j] = v[j], v[i] }
 
+type sortByLess struct {
+	sortByString
+	less Less
+}
+
+func (v sortByLess) Less(i, j int) bool {
+	return v.less(v.sortByString[i].name, v.sortByString[j].name)
+}
+
 type sortNext struct {
 	namer     refs.Namer
 	subIt     Scanner
+	less      Less
+	desc      bool
 	ordered   sortByString
 	result    result
 	err       error
@@ -84,10 +116,12 @@ type sortNext struct {
 	pathIndex int
 }
 
-func newSortNext(namer refs.Namer, subIt Scanner) *sortNext {
+func newSortNext(namer refs.Namer, subIt Scanner, less Less, desc bool) *sortNext {
 	return &sortNext{
 		namer:     namer,
 		subIt:     subIt,
+		less:      less,
+		desc:      desc,
 		pathIndex: -1,
 	}
 }
@@ -111,7 +145,7 @@ func (it *sortNext) Next(ctx context.Context) bool {
 		return false
 	}
 	if it.ordered == nil {
-		v, err := getSortedValues(ctx, it.namer, it.subIt)
+		v, err := getSortedValues(ctx, it.namer, it.subIt, it.less, it.desc)
 		it.ordered = v
 		it.err = err
 		if it.err != nil {
@@ -149,7 +183,7 @@ func (it *sortNext) String() string {
 	return "SortNext"
 }
 
-func getSortedValues(ctx context.Context, namer refs.Namer, it Scanner) (sortByString, error) {
+func getSortedValues(ctx context.Context, namer refs.Namer, it Scanner, less Less, desc bool) (sortByString, error) {
 	var v sortByString
 	for it.Next(ctx) {
 		id := it.Result()
@@ -160,6 +194,7 @@ func getSortedValues(ctx context.Context, namer refs.Namer, it Scanner) (sortByS
 		it.TagResults(tags)
 		val := sortValue{
 			result: result{id, tags},
+			name:   name,
 			str:    str,
 		}
 		for it.NextPath(ctx) {
@@ -172,6 +207,16 @@ func getSortedValues(ctx context.Context, namer refs.Namer, it Scanner) (sortByS
 	if err := it.Err(); err != nil {
 		return v, err
 	}
-	sort.Sort(v)
+	if less != nil {
+		var data sort.Interface = sortByLess{v, less}
+		if desc {
+			data = sort.Reverse(data)
+		}
+		sort.Stable(data)
+	} else if desc {
+		sort.Sort(sort.Reverse(v))
+	} else {
+		sort.Sort(v)
+	}
 	return v, nil
 }
@@ -109,6 +109,9 @@ func (it *valueFilterNext) Next(ctx context.Context) bool {
 			it.result = val
 			return true
 		}
+		if it.err != nil {
+			return false
+		}
 	}
 	it.err = it.sub.Err()
 	return false
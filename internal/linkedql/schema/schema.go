@@ -25,6 +25,9 @@ func typeToRange(t reflect.Type) string {
 	if t.Kind() == reflect.Slice {
 		return typeToRange(t.Elem())
 	}
+	if t.Kind() == reflect.Map {
+		return rdfs.Resource
+	}
 	if t.Kind() == reflect.String {
 		return xsd.String
 	}
@@ -37,6 +40,9 @@ func typeToRange(t reflect.Type) string {
 	if t.Implements(pathStep) {
 		return linkedql.Prefix + "PathStep"
 	}
+	if t.Implements(iteratorStep) {
+		return linkedql.Prefix + "IteratorStep"
+	}
 	if t.Implements(operator) {
 		return linkedql.Prefix + "Operator"
 	}
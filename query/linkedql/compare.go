@@ -0,0 +1,422 @@
+package linkedql
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/cayleygraph/cayley/graph"
+	"github.com/cayleygraph/cayley/graph/iterator"
+	"github.com/cayleygraph/cayley/query/path"
+	"github.com/cayleygraph/cayley/query/shape"
+	"github.com/cayleygraph/quad"
+)
+
+// valueKind is the kind of values that can be ordered together.
+type valueKind int
+
+const (
+	otherKind valueKind = iota
+	numberKind
+	timeKind
+	stringKind
+	iriKind
+	bnodeKind
+)
+
+func (k valueKind) String() string {
+	switch k {
+	case numberKind:
+		return "number"
+	case timeKind:
+		return "time"
+	case stringKind:
+		return "string"
+	case iriKind:
+		return "IRI"
+	case bnodeKind:
+		return "blank node"
+	}
+	return "value"
+}
+
+func kindOf(v quad.Value) valueKind {
+	switch v := v.(type) {
+	case quad.Int, quad.Float:
+		return numberKind
+	case quad.Time:
+		return timeKind
+	case quad.TypedString:
+		if _, ok := timeOf(v); ok {
+			return timeKind
+		}
+	case quad.String, quad.LangString:
+		return stringKind
+	case quad.IRI:
+		return iriKind
+	case quad.BNode:
+		return bnodeKind
+	}
+	return otherKind
+}
+
+var _ shape.ValueFilter = comparisonFilter{}
+
+// comparisonFilter is a value filter keeping values for which the comparison with value holds.
+// Integers and floats are compared numerically. Values of another kind than value are skipped.
+// It can not be optimized by quad stores, comparisonPath should be used instead where possible.
+type comparisonFilter struct {
+	op    iterator.Operator
+	value quad.Value
+}
+
+func (f comparisonFilter) BuildIterator(qs graph.QuadStore, it iterator.Shape) iterator.Shape {
+	return iterator.NewValueFilter(qs, it, func(v quad.Value) (bool, error) {
+		return f.holds(v), nil
+	})
+}
+
+// holds reports whether the comparison holds for v.
+func (f comparisonFilter) holds(v quad.Value) bool {
+	kind := kindOf(f.value)
+	if kind == otherKind {
+		return compareOp(strings.Compare(quad.StringOf(v), quad.StringOf(f.value)), f.op)
+	}
+	if kindOf(v) != kind {
+		return false
+	}
+	return compareOp(compareSameKind(v, f.value), f.op)
+}
+
+// checkComparable returns an IncomparableError if v is a number, a time or a string of another kind than with.
+func checkComparable(v, with quad.Value) error {
+	switch kind := kindOf(v); kind {
+	case numberKind, timeKind, stringKind:
+		if kind != kindOf(with) {
+			return &IncomparableError{Value: v, With: with}
+		}
+	}
+	return nil
+}
+
+var _ shape.ValueFilter = incomparableFilter{}
+
+// incomparableFilter is a value filter keeping no value, which fails with an IncomparableError
+// on the first number, time or string of another kind than with.
+type incomparableFilter struct {
+	with quad.Value
+}
+
+func (f incomparableFilter) BuildIterator(qs graph.QuadStore, it iterator.Shape) iterator.Shape {
+	return iterator.NewValueFilter(qs, it, func(v quad.Value) (bool, error) {
+		return false, checkComparable(v, f.with)
+	})
+}
+
+var _ shape.ValueFilter = comparisonsFilter(nil)
+
+// comparisonsFilter is a value filter keeping values for which all the comparisons hold.
+// It fails with an IncomparableError on the first number, time or string of another kind
+// than the compared values.
+type comparisonsFilter []comparisonFilter
+
+func (f comparisonsFilter) BuildIterator(qs graph.QuadStore, it iterator.Shape) iterator.Shape {
+	return iterator.NewValueFilter(qs, it, func(v quad.Value) (bool, error) {
+		if err := checkComparable(v, f[0].value); err != nil {
+			return false, err
+		}
+		for _, c := range f {
+			if !c.holds(v) {
+				return false, nil
+			}
+		}
+		return true, nil
+	})
+}
+
+// comparisonPath returns the values of from for which all the comparisons hold.
+// The comparisons are built with shape.Comparison, which quad stores can optimize, once for
+// each type of values of the compared kind: integers are compared with integer bounds and floats
+// with float bounds. Date time typed strings and language tagged strings, which shape.Comparison
+// does not compare with times and strings, are matched by a separate filter. Values of another
+// kind than a number, a time, a string, an IRI or a blank node are compared by their string form.
+// All the comparisons must be of values of the same kind. Resolving a number, a time or a string
+// of another kind fails with an IncomparableError, and other values of another kind are skipped.
+// Morphisms are filtered by a single comparisonsFilter instead.
+func comparisonPath(from *path.Path, cmps ...comparisonFilter) (*path.Path, error) {
+	kind := kindOf(cmps[0].value)
+	for _, c := range cmps[1:] {
+		if kindOf(c.value) != kind {
+			return nil, &IncomparableError{Value: c.value, With: cmps[0].value}
+		}
+	}
+	if from.IsMorphism() {
+		// the branches of a union would not all be applied to the values the morphism is applied to
+		return from.Filters(comparisonsFilter(cmps)), nil
+	}
+	var branches []*path.Path
+	switch kind {
+	case numberKind:
+		var ints, floats []shape.ValueFilter
+		noInts := false
+		for _, c := range cmps {
+			f, _ := toFloat(c.value)
+			floats = append(floats, shape.Comparison{Op: c.op, Val: quad.Float(f)})
+			if i, ok := c.value.(quad.Int); ok {
+				ints = append(ints, shape.Comparison{Op: c.op, Val: i})
+				continue
+			}
+			bound, all, none := intBound(c.op, f)
+			noInts = noInts || none
+			if !all {
+				ints = append(ints, shape.Comparison{Op: c.op, Val: bound})
+			}
+		}
+		if !noInts {
+			branches = append(branches, from.Filters(ints...))
+		}
+		branches = append(branches, from.Filters(floats...))
+	case timeKind:
+		var times []shape.ValueFilter
+		for _, c := range cmps {
+			t, _ := timeOf(c.value)
+			times = append(times, shape.Comparison{Op: c.op, Val: quad.Time(t)})
+		}
+		branches = append(branches,
+			from.Filters(times...),
+			from.Filters(variantFilter(cmps, func(v quad.Value) bool {
+				_, ok := v.(quad.TypedString)
+				return ok
+			})),
+		)
+	case stringKind:
+		var strs []shape.ValueFilter
+		for _, c := range cmps {
+			strs = append(strs, shape.Comparison{Op: c.op, Val: quad.String(stringOf(c.value))})
+		}
+		branches = append(branches,
+			from.Filters(strs...),
+			from.Filters(variantFilter(cmps, func(v quad.Value) bool {
+				_, ok := v.(quad.LangString)
+				return ok
+			})),
+		)
+	default:
+		var filters []shape.ValueFilter
+		for _, c := range cmps {
+			filters = append(filters, shape.Comparison{Op: c.op, Val: c.value})
+		}
+		branches = append(branches, from.Filters(filters...))
+	}
+	if kind != otherKind {
+		// checked first, to fail before resolving any value
+		branches = append([]*path.Path{from.Filters(incomparableFilter{with: cmps[0].value})}, branches...)
+	}
+	p := branches[0]
+	for _, b := range branches[1:] {
+		p = p.Or(b)
+	}
+	return p, nil
+}
+
+// variantFilter returns a value filter keeping the values accepted by typ for which all the comparisons hold.
+func variantFilter(cmps []comparisonFilter, typ func(v quad.Value) bool) valueFilter {
+	return func(v quad.Value) bool {
+		if !typ(v) {
+			return false
+		}
+		for _, c := range cmps {
+			if !c.holds(v) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// toFloat returns the value of a number as a float.
+func toFloat(v quad.Value) (float64, bool) {
+	switch v := v.(type) {
+	case quad.Int:
+		return float64(v), true
+	case quad.Float:
+		return float64(v), true
+	}
+	return 0, false
+}
+
+// intBound returns the integer bound for which comparing integers with op gives the same result
+// as comparing them with f. If all is set every integer satisfies the comparison and if none is
+// set no integer does.
+func intBound(op iterator.Operator, f float64) (bound quad.Int, all, none bool) {
+	if math.IsNaN(f) {
+		return 0, false, true
+	}
+	var b float64
+	switch op {
+	case iterator.CompareLT, iterator.CompareGTE:
+		b = math.Ceil(f)
+	default:
+		b = math.Floor(f)
+	}
+	switch {
+	case b >= math.MaxInt64:
+		// every integer is below the bound
+		lower := op == iterator.CompareLT || op == iterator.CompareLTE
+		return 0, lower, !lower
+	case b < math.MinInt64:
+		// every integer is above the bound
+		upper := op == iterator.CompareGT || op == iterator.CompareGTE
+		return 0, upper, !upper
+	}
+	return quad.Int(b), false, false
+}
+
+// compareSameKind compares two values of the same kind.
+func compareSameKind(a, b quad.Value) int {
+	switch a := a.(type) {
+	case quad.IRI:
+		return strings.Compare(string(a), string(b.(quad.IRI)))
+	case quad.BNode:
+		return strings.Compare(string(a), string(b.(quad.BNode)))
+	case quad.String, quad.LangString:
+		return strings.Compare(stringOf(a), stringOf(b))
+	}
+	return compareValues(a, b)
+}
+
+// stringOf returns the string of a string or language tagged string value.
+func stringOf(v quad.Value) string {
+	switch v := v.(type) {
+	case quad.String:
+		return string(v)
+	case quad.LangString:
+		return string(v.Value)
+	}
+	return quad.StringOf(v)
+}
+
+// compareOp reports whether the result of a comparison satisfies op.
+func compareOp(c int, op iterator.Operator) bool {
+	switch op {
+	case iterator.CompareLT:
+		return c < 0
+	case iterator.CompareLTE:
+		return c <= 0
+	case iterator.CompareGT:
+		return c > 0
+	case iterator.CompareGTE:
+		return c >= 0
+	}
+	panic("unknown operator type")
+}
+
+// compareValues compares two quad values returning -1 if a is less than b, 0 if they are equal and 1 if a is greater than b.
+// Numeric values are compared by their numeric value, times and date time typed strings chronologically
+// and all other values by their string form.
+func compareValues(a, b quad.Value) int {
+	if ta, ok := timeOf(a); ok {
+		if tb, ok := timeOf(b); ok {
+			return compareTimes(ta, tb)
+		}
+	}
+	switch a := a.(type) {
+	case quad.Int:
+		switch b := b.(type) {
+		case quad.Int:
+			return compareFloats(float64(a), float64(b))
+		case quad.Float:
+			return compareFloats(float64(a), float64(b))
+		}
+	case quad.Float:
+		switch b := b.(type) {
+		case quad.Int:
+			return compareFloats(float64(a), float64(b))
+		case quad.Float:
+			return compareFloats(float64(a), float64(b))
+		}
+	}
+	return strings.Compare(quad.StringOf(a), quad.StringOf(b))
+}
+
+// timeOf returns the time of a time value or of a string typed as a date time.
+func timeOf(v quad.Value) (time.Time, bool) {
+	switch v := v.(type) {
+	case quad.Time:
+		return time.Time(v), true
+	case quad.TypedString:
+		if pv, err := v.ParseValue(); err == nil {
+			if t, ok := pv.(quad.Time); ok {
+				return time.Time(t), true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+func compareFloats(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func compareTimes(a, b time.Time) int {
+	switch {
+	case a.Before(b):
+		return -1
+	case a.After(b):
+		return 1
+	}
+	return 0
+}
+
+// collation returns the comparator for the named collation.
+// A nil comparator is returned for the default binary collation.
+func collation(name string) (iterator.Less, error) {
+	switch name {
+	case "", "binary":
+		return nil, nil
+	case "case-insensitive":
+		return func(a, b quad.Value) bool {
+			la, lb := strings.ToLower(a.String()), strings.ToLower(b.String())
+			if la != lb {
+				return la < lb
+			}
+			return a.String() < b.String()
+		}, nil
+	case "numeric":
+		return func(a, b quad.Value) bool {
+			na, nb := kindOf(a) == numberKind, kindOf(b) == numberKind
+			switch {
+			case na && nb:
+				if c := compareValues(a, b); c != 0 {
+					return c < 0
+				}
+			case na != nb:
+				return na
+			}
+			return a.String() < b.String()
+		}, nil
+	}
+	return nil, fmt.Errorf("unknown collation: %q", name)
+}
+
+// comparisonOperator returns the comparison operator with the given short name.
+func comparisonOperator(name string) (iterator.Operator, error) {
+	switch name {
+	case "gt":
+		return iterator.CompareGT, nil
+	case "gte":
+		return iterator.CompareGTE, nil
+	case "lt":
+		return iterator.CompareLT, nil
+	case "lte":
+		return iterator.CompareLTE, nil
+	}
+	return 0, fmt.Errorf("unknown comparison operator: %q", name)
+}
@@ -0,0 +1,184 @@
+package linkedql
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/cayleygraph/cayley/graph"
+	"github.com/cayleygraph/cayley/query"
+	"github.com/cayleygraph/quad"
+	"github.com/cayleygraph/quad/voc"
+	"github.com/cayleygraph/quad/voc/owl"
+	"github.com/cayleygraph/quad/voc/rdf"
+	"github.com/cayleygraph/quad/voc/rdfs"
+	"github.com/cayleygraph/quad/voc/schema"
+	"github.com/cayleygraph/quad/voc/xsd"
+)
+
+var (
+	entityIdentifierStringType = reflect.TypeOf(EntityIdentifierString(""))
+	entityIRIType              = reflect.TypeOf(EntityIRI(""))
+	graphPatternType           = reflect.TypeOf(GraphPattern{})
+)
+
+// ExpandContexts expands the IRIs used in item with the rules of the Context steps nested in it.
+// IRIs equal to a term are replaced with the IRI of the term and IRIs starting with a term followed
+// by a colon are expanded using the IRI of the term as a namespace.
+// Rules apply to the whole query, regardless of where they are defined.
+func ExpandContexts(item RegistryItem) error {
+	return ExpandContextsWith(item, nil)
+}
+
+// ExpandContextsWith is the same as ExpandContexts, but also expands IRIs using the default namespaces.
+// Rules of the Context steps extend the default namespaces and take precedence over them.
+func ExpandContextsWith(item RegistryItem, defaults *voc.Namespaces) error {
+	terms := make(map[string]string)
+	ns := &voc.Namespaces{}
+	if defaults != nil {
+		defaults.CloneTo(ns)
+	}
+	err := walkItems(item, func(item RegistryItem) error {
+		c, ok := item.(*Context)
+		if !ok {
+			return nil
+		}
+		for term, iri := range c.Rules {
+			if prev, ok := terms[term]; ok && prev != iri {
+				return fmt.Errorf("context term %q is defined more than once", term)
+			}
+			terms[term] = iri
+			ns.Register(voc.Namespace{Prefix: term + ":", Full: iri})
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if len(terms) == 0 && (defaults == nil || len(defaults.List()) == 0) {
+		return nil
+	}
+	expand := func(iri string) string {
+		if full, ok := terms[iri]; ok {
+			return full
+		}
+		return ns.FullIRI(iri)
+	}
+	return walkItems(item, func(item RegistryItem) error {
+		v := reflect.ValueOf(item)
+		if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+			return nil
+		}
+		expandFields(v.Elem(), expand)
+		return nil
+	})
+}
+
+// DefaultNamespaces returns the namespaces of common vocabularies (rdf, rdfs, owl, xsd and schema).
+func DefaultNamespaces() *voc.Namespaces {
+	ns := &voc.Namespaces{}
+	for _, n := range []voc.Namespace{
+		{Prefix: rdf.Prefix, Full: rdf.NS},
+		{Prefix: rdfs.Prefix, Full: rdfs.NS},
+		{Prefix: owl.Prefix, Full: owl.NS},
+		{Prefix: xsd.Prefix, Full: xsd.NS},
+		{Prefix: schema.Prefix, Full: schema.NS},
+	} {
+		ns.Register(n)
+	}
+	return ns
+}
+
+// BuildIteratorWithNamespaces builds the iterator of step after expanding its IRIs using the
+// default namespaces, extended with the rules of the Context steps nested in it.
+func BuildIteratorWithNamespaces(step IteratorStep, qs graph.QuadStore, defaults *voc.Namespaces) (query.Iterator, error) {
+	if err := ExpandContextsWith(step, defaults); err != nil {
+		return nil, err
+	}
+	return step.BuildIterator(qs)
+}
+
+func expandFields(v reflect.Value, expand func(iri string) string) {
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Type().Field(i)
+		if f.Anonymous && f.Type.Kind() == reflect.Struct {
+			expandFields(v.Field(i), expand)
+			continue
+		}
+		if f.PkgPath != "" {
+			// unexported
+			continue
+		}
+		expandValue(v.Field(i), expand)
+	}
+}
+
+// expandValue expands the IRIs held by v. Nested steps are expanded separately.
+func expandValue(v reflect.Value, expand func(iri string) string) {
+	switch v.Type() {
+	case quadIRI, entityIRIType:
+		v.SetString(expand(v.String()))
+		return
+	case quadValue, entityIdentifierType:
+		if v.IsNil() {
+			return
+		}
+		switch e := v.Elem(); e.Type() {
+		case quadIRI, entityIRIType:
+			v.Set(reflect.ValueOf(expand(e.String())).Convert(e.Type()))
+		case entityIdentifierStringType:
+			if !strings.HasPrefix(e.String(), "_:") {
+				v.Set(reflect.ValueOf(expand(e.String())).Convert(e.Type()))
+			}
+		}
+		return
+	case propertyPathType:
+		expandPropertyPath(v.Addr().Interface().(*PropertyPath), expand)
+		return
+	case graphPatternType:
+		if !v.IsNil() {
+			v.Set(reflect.ValueOf(expandGraphPattern(v.Interface().(GraphPattern), expand)))
+		}
+		return
+	}
+	if v.Kind() == reflect.Slice {
+		for i := 0; i < v.Len(); i++ {
+			expandValue(v.Index(i), expand)
+		}
+	}
+}
+
+// expandPropertyPath expands the IRIs of the properties in p.
+func expandPropertyPath(p *PropertyPath, expand func(iri string) string) {
+	switch pp := p.p.(type) {
+	case PropertyIRIs:
+		iris := make(PropertyIRIs, len(pp))
+		for i, iri := range pp {
+			iris[i] = quad.IRI(expand(string(iri)))
+		}
+		p.p = iris
+	case PropertyIRIStrings:
+		iris := make(PropertyIRIStrings, len(pp))
+		for i, iri := range pp {
+			iris[i] = expand(iri)
+		}
+		p.p = iris
+	case PropertyIRI:
+		p.p = PropertyIRI(expand(string(pp)))
+	case PropertyIRIString:
+		p.p = PropertyIRIString(expand(string(pp)))
+	case PropertySequence:
+		seq := make(PropertySequence, len(pp))
+		copy(seq, pp)
+		for i := range seq {
+			expandPropertyPath(&seq[i], expand)
+		}
+		p.p = seq
+	case PropertyInverse:
+		expandPropertyPath(&pp.Property, expand)
+		p.p = pp
+	case PropertyZeroOrMore:
+		expandPropertyPath(&pp.Property, expand)
+		p.p = pp
+	}
+}
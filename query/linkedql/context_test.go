@@ -0,0 +1,147 @@
+package linkedql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cayleygraph/cayley/graph/memstore"
+	"github.com/cayleygraph/cayley/query"
+	"github.com/cayleygraph/quad"
+	"github.com/stretchr/testify/require"
+)
+
+var contextData = []quad.Quad{
+	quad.MakeIRI("http://example.org/alice", "http://example.org/likes", "http://example.org/bob", ""),
+	quad.MakeIRI("http://example.org/carol", "http://example.org/likes", "http://example.org/dan", ""),
+}
+
+func TestExpandContexts(t *testing.T) {
+	step := &Has{
+		From: &Context{
+			From:  &Vertex{},
+			Rules: map[string]string{"ex": "http://example.org/", "likes": "http://example.org/likes"},
+		},
+		Property: PropertyPath{PropertyIRIString("likes")},
+		Values:   []quad.Value{quad.IRI("ex:bob")},
+	}
+	require.NoError(t, ExpandContexts(step))
+	require.Equal(t, PropertyPath{PropertyIRIString("http://example.org/likes")}, step.Property)
+	require.Equal(t, []quad.Value{quad.IRI("http://example.org/bob")}, step.Values)
+	it, err := step.BuildIterator(memstore.New(contextData...))
+	require.NoError(t, err)
+	ctx := context.TODO()
+	var results []interface{}
+	for it.Next(ctx) {
+		results = append(results, it.Result())
+	}
+	require.NoError(t, it.Err())
+	require.Equal(t, []interface{}{map[string]string{"@id": "http://example.org/alice"}}, results)
+}
+
+func TestExpandContextsPropertySequence(t *testing.T) {
+	step := &Visit{
+		From: &Context{
+			From:  &Vertex{},
+			Rules: map[string]string{"ex": "http://example.org/"},
+		},
+		Properties: PropertyPath{PropertySequence{
+			PropertyPath{PropertyIRIString("ex:likes")},
+			PropertyPath{PropertyIRIs{quad.IRI("ex:name")}},
+		}},
+	}
+	require.NoError(t, ExpandContexts(step))
+	require.Equal(t, PropertyPath{PropertySequence{
+		PropertyPath{PropertyIRIString("http://example.org/likes")},
+		PropertyPath{PropertyIRIs{quad.IRI("http://example.org/name")}},
+	}}, step.Properties)
+}
+
+func TestExpandContextsConflict(t *testing.T) {
+	step := &Context{
+		From:  &Context{From: &Vertex{}, Rules: map[string]string{"ex": "http://example.org/"}},
+		Rules: map[string]string{"ex": "http://example.com/"},
+	}
+	require.Error(t, ExpandContexts(step))
+}
+
+func TestExecuteExpandsContexts(t *testing.T) {
+	s := NewSession(memstore.New(contextData...))
+	it, err := s.Execute(context.TODO(), `{
+		"@type": "linkedql:Has",
+		"linkedql:property": "ex:likes",
+		"linkedql:values": [{"@id": "ex:bob"}],
+		"linkedql:from": {
+			"@type": "linkedql:Context",
+			"linkedql:rules": {"ex": "http://example.org/"},
+			"linkedql:from": {"@type": "linkedql:Vertex"}
+		}
+	}`, query.Options{})
+	require.NoError(t, err)
+	ctx := context.TODO()
+	var results []interface{}
+	for it.Next(ctx) {
+		results = append(results, it.Result())
+	}
+	require.NoError(t, it.Err())
+	require.Equal(t, []interface{}{map[string]string{"@id": "http://example.org/alice"}}, results)
+}
+
+var schemaData = []quad.Quad{
+	quad.MakeIRI("http://example.org/alice", "http://schema.org/name", "Alice", ""),
+}
+
+func TestBuildIteratorWithNamespaces(t *testing.T) {
+	step := &Visit{
+		From: &Context{
+			From:  &Vertex{Values: []quad.Value{quad.IRI("ex:alice")}},
+			Rules: map[string]string{"ex": "http://example.org/"},
+		},
+		Properties: PropertyPath{PropertyIRIString("schema:name")},
+	}
+	it, err := BuildIteratorWithNamespaces(step, memstore.New(schemaData...), DefaultNamespaces())
+	require.NoError(t, err)
+	ctx := context.TODO()
+	var results []interface{}
+	for it.Next(ctx) {
+		results = append(results, it.Result())
+	}
+	require.NoError(t, it.Err())
+	require.Equal(t, []interface{}{map[string]string{"@id": "Alice"}}, results)
+}
+
+func TestExecuteDefaultNamespaces(t *testing.T) {
+	s := NewSession(memstore.New(schemaData...))
+	s.Namespaces = DefaultNamespaces()
+	it, err := s.Execute(context.TODO(), `{
+		"@type": "linkedql:Visit",
+		"linkedql:properties": "schema:name",
+		"linkedql:from": {
+			"@type": "linkedql:Vertex",
+			"linkedql:values": [{"@id": "http://example.org/alice"}]
+		}
+	}`, query.Options{})
+	require.NoError(t, err)
+	ctx := context.TODO()
+	var results []interface{}
+	for it.Next(ctx) {
+		results = append(results, it.Result())
+	}
+	require.NoError(t, it.Err())
+	require.Equal(t, []interface{}{map[string]string{"@id": "Alice"}}, results)
+}
+
+func TestExpandContextsGraphPattern(t *testing.T) {
+	step := &Match{
+		From: &Context{
+			From:  &Vertex{},
+			Rules: map[string]string{"ex": "http://example.org/"},
+		},
+		GraphPattern: GraphPattern{
+			"ex:likes": map[string]interface{}{"@id": "ex:bob"},
+		},
+	}
+	require.NoError(t, ExpandContexts(step))
+	require.Equal(t, GraphPattern{
+		"http://example.org/likes": map[string]interface{}{"@id": "http://example.org/bob"},
+	}, step.GraphPattern)
+}
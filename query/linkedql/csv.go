@@ -0,0 +1,87 @@
+package linkedql
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+	"sort"
+
+	"github.com/cayleygraph/quad"
+)
+
+// WriteCSV writes the results of it to w as CSV. The first row is a header with the tag names.
+// IRIs are written as is and literals as their lexical value. Missing tags are written as empty cells.
+// If it has no selected tags all the tags of the results are written, which requires reading all of them first.
+func WriteCSV(ctx context.Context, w io.Writer, it *TagsIterator) error {
+	cw := csv.NewWriter(w)
+	header := it.selected
+	var rows []map[string]quad.Value
+	if header == nil {
+		// collect the tags of all the results to build the header
+		seen := make(map[string]struct{})
+		for it.Next(ctx) {
+			row := it.getTagValues()
+			for tag := range row {
+				if _, ok := seen[tag]; !ok {
+					seen[tag] = struct{}{}
+					header = append(header, tag)
+				}
+			}
+			rows = append(rows, row)
+		}
+		if err := it.Err(); err != nil {
+			return err
+		}
+		sort.Strings(header)
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	record := make([]string, len(header))
+	writeRow := func(row map[string]quad.Value) error {
+		for i, tag := range header {
+			record[i] = lexicalForm(row[tag])
+		}
+		return cw.Write(record)
+	}
+	if rows != nil {
+		for _, row := range rows {
+			if err := writeRow(row); err != nil {
+				return err
+			}
+		}
+	} else {
+		for it.Next(ctx) {
+			if err := writeRow(it.getTagValues()); err != nil {
+				return err
+			}
+		}
+		if err := it.Err(); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// lexicalForm returns the string form of a value without quotes, brackets or types.
+// It is used for CSV cells and joined strings.
+func lexicalForm(v quad.Value) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case quad.IRI:
+		return string(v)
+	case quad.BNode:
+		return v.String()
+	case quad.String:
+		return string(v)
+	case quad.LangString:
+		return string(v.Value)
+	case quad.TypedString:
+		return string(v.Value)
+	case quad.TypedStringer:
+		return string(v.TypedString().Value)
+	}
+	return quad.StringOf(v)
+}
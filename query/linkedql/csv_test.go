@@ -0,0 +1,48 @@
+package linkedql
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/cayleygraph/cayley/graph/memstore"
+	"github.com/cayleygraph/quad"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteCSV(t *testing.T) {
+	store := memstore.New(
+		quad.MakeIRI("alice", "likes", "bob", ""),
+		quad.Make(quad.IRI("bob"), quad.IRI("age"), quad.Int(32), nil),
+	)
+	step := &Select{
+		Tags: []string{"liker", "liked", "age"},
+		From: &SaveOptional{
+			From: &As{
+				From: &Visit{
+					From: &As{
+						From: &Vertex{Values: []quad.Value{quad.IRI("alice")}},
+						Name: "liker",
+					},
+					Properties: PropertyPath{PropertyIRIString("likes")},
+				},
+				Name: "liked",
+			},
+			Property: PropertyPath{PropertyIRIString("age")},
+			Tag:      "age",
+		},
+	}
+	it, err := step.BuildIterator(store)
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	require.NoError(t, WriteCSV(context.TODO(), &buf, it.(*TagsIterator)))
+	require.Equal(t, "liker,liked,age\nalice,bob,32\n", buf.String())
+
+	// missing tags are written as empty cells
+	step.Tags = []string{"liker", "missing"}
+	it, err = step.BuildIterator(store)
+	require.NoError(t, err)
+	buf.Reset()
+	require.NoError(t, WriteCSV(context.TODO(), &buf, it.(*TagsIterator)))
+	require.Equal(t, "liker,missing\nalice,\n", buf.String())
+}
@@ -0,0 +1,140 @@
+package linkedql
+
+import (
+	"context"
+
+	"github.com/cayleygraph/cayley/graph"
+	"github.com/cayleygraph/cayley/graph/iterator"
+	"github.com/cayleygraph/cayley/graph/refs"
+	"github.com/cayleygraph/cayley/query/path"
+	"github.com/cayleygraph/cayley/query/shape"
+)
+
+var _ shape.ValueFilter = degreeFilter{}
+
+// degreeFilter is a value filter keeping nodes with at least min outgoing edges of via.
+// If reverse is set incoming edges are counted instead.
+type degreeFilter struct {
+	via     *path.Path
+	min     int
+	reverse bool
+}
+
+func (f degreeFilter) BuildIterator(qs graph.QuadStore, it iterator.Shape) iterator.Shape {
+	return &degreeShape{qs: qs, sub: it, filter: f}
+}
+
+// holds reports whether the node has at least min edges, counting them with the context of the caller.
+func (f degreeFilter) holds(ctx context.Context, qs graph.QuadStore, ref refs.Ref) (bool, error) {
+	p := path.StartPath(qs, qs.NameOf(ref))
+	if f.reverse {
+		p = p.In(f.via)
+	} else {
+		p = p.Out(f.via)
+	}
+	it := p.BuildIterator(ctx).Iterate()
+	defer it.Close()
+	n := 0
+	for n < f.min && it.Next(ctx) {
+		n++
+	}
+	return n >= f.min, it.Err()
+}
+
+var _ iterator.Shape = (*degreeShape)(nil)
+
+// degreeShape is an iterator of the values of sub passing a degreeFilter.
+type degreeShape struct {
+	qs     graph.QuadStore
+	sub    iterator.Shape
+	filter degreeFilter
+}
+
+func (it *degreeShape) Iterate() iterator.Scanner {
+	return &degreeNext{shape: it, sub: it.sub.Iterate()}
+}
+
+func (it *degreeShape) Lookup() iterator.Index {
+	return &degreeContains{shape: it, sub: it.sub.Lookup()}
+}
+
+func (it *degreeShape) Stats(ctx context.Context) (iterator.Costs, error) {
+	st, err := it.sub.Stats(ctx)
+	st.Size.Value = st.Size.Value/2 + 1
+	st.Size.Exact = false
+	return st, err
+}
+
+func (it *degreeShape) Optimize(ctx context.Context) (iterator.Shape, bool) {
+	if nsub, ok := it.sub.Optimize(ctx); ok {
+		it.sub = nsub
+	}
+	return it, true
+}
+
+func (it *degreeShape) SubIterators() []iterator.Shape {
+	return []iterator.Shape{it.sub}
+}
+
+func (it *degreeShape) String() string {
+	return "Degree"
+}
+
+type degreeNext struct {
+	shape  *degreeShape
+	sub    iterator.Scanner
+	result refs.Ref
+	err    error
+}
+
+func (it *degreeNext) Next(ctx context.Context) bool {
+	for it.sub.Next(ctx) {
+		ref := it.sub.Result()
+		ok, err := it.shape.filter.holds(ctx, it.shape.qs, ref)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		if ok {
+			it.result = ref
+			return true
+		}
+	}
+	it.err = it.sub.Err()
+	return false
+}
+
+func (it *degreeNext) NextPath(ctx context.Context) bool  { return it.sub.NextPath(ctx) }
+func (it *degreeNext) TagResults(dst map[string]refs.Ref) { it.sub.TagResults(dst) }
+func (it *degreeNext) Result() refs.Ref                   { return it.result }
+func (it *degreeNext) Err() error                         { return it.err }
+func (it *degreeNext) Close() error                       { return it.sub.Close() }
+func (it *degreeNext) String() string                     { return "DegreeNext" }
+
+type degreeContains struct {
+	shape  *degreeShape
+	sub    iterator.Index
+	result refs.Ref
+	err    error
+}
+
+func (it *degreeContains) Contains(ctx context.Context, ref refs.Ref) bool {
+	ok, err := it.shape.filter.holds(ctx, it.shape.qs, ref)
+	if err != nil {
+		it.err = err
+		return false
+	}
+	if !ok || !it.sub.Contains(ctx, ref) {
+		it.err = it.sub.Err()
+		return false
+	}
+	it.result = ref
+	return true
+}
+
+func (it *degreeContains) NextPath(ctx context.Context) bool  { return it.sub.NextPath(ctx) }
+func (it *degreeContains) TagResults(dst map[string]refs.Ref) { it.sub.TagResults(dst) }
+func (it *degreeContains) Result() refs.Ref                   { return it.result }
+func (it *degreeContains) Err() error                         { return it.err }
+func (it *degreeContains) Close() error                       { return it.sub.Close() }
+func (it *degreeContains) String() string                     { return "DegreeContains" }
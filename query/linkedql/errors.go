@@ -1,6 +1,10 @@
 package linkedql
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/cayleygraph/quad"
+)
 
 func formatMultiError(errors []error) error {
 	joinedErr := ""
@@ -9,3 +13,24 @@ func formatMultiError(errors []error) error {
 	}
 	return fmt.Errorf("Could not parse PropertyPath: %v", joinedErr)
 }
+
+// ValidationError is returned when a field of a step does not satisfy its cardinality.
+type ValidationError struct {
+	Step   quad.IRI
+	Field  string
+	Reason string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s %s", e.Step, e.Field, e.Reason)
+}
+
+// IncomparableError is returned when a value is compared with a value of another kind.
+type IncomparableError struct {
+	Value quad.Value
+	With  quad.Value
+}
+
+func (e *IncomparableError) Error() string {
+	return fmt.Sprintf("cannot compare %s %v with %s %v", kindOf(e.Value), e.Value, kindOf(e.With), e.With)
+}
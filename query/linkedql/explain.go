@@ -0,0 +1,43 @@
+package linkedql
+
+import (
+	"context"
+
+	"github.com/cayleygraph/cayley/graph"
+	"github.com/cayleygraph/cayley/graph/iterator"
+)
+
+// Explanation describes an operation of the plan of a query.
+type Explanation struct {
+	// Operation is a short description of the operation.
+	Operation string `json:"operation"`
+	// Size is the estimated number of results of the operation.
+	Size int64 `json:"size"`
+	// ExactSize is set if Size is known to be exact.
+	ExactSize bool `json:"exactSize,omitempty"`
+	// SubOperations are the operations the operation consumes.
+	SubOperations []Explanation `json:"subOperations,omitempty"`
+}
+
+// Explain returns the plan of step for the QuadStore without executing it.
+// Sizes are only estimated if the QuadStore supports it.
+func Explain(ctx context.Context, qs graph.QuadStore, step PathStep) (*Explanation, error) {
+	p, err := step.BuildPath(qs)
+	if err != nil {
+		return nil, err
+	}
+	e := explainShape(ctx, p.BuildIterator(ctx))
+	return &e, nil
+}
+
+func explainShape(ctx context.Context, it iterator.Shape) Explanation {
+	e := Explanation{Operation: it.String()}
+	if st, err := it.Stats(ctx); err == nil {
+		e.Size = st.Size.Value
+		e.ExactSize = st.Size.Exact
+	}
+	for _, sub := range it.SubIterators() {
+		e.SubOperations = append(e.SubOperations, explainShape(ctx, sub))
+	}
+	return e
+}
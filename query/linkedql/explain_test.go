@@ -0,0 +1,33 @@
+package linkedql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cayleygraph/cayley/graph/memstore"
+	"github.com/cayleygraph/quad"
+	"github.com/stretchr/testify/require"
+)
+
+func operations(e Explanation) []string {
+	out := []string{e.Operation}
+	for _, sub := range e.SubOperations {
+		out = append(out, operations(sub)...)
+	}
+	return out
+}
+
+func TestExplain(t *testing.T) {
+	store := memstore.New(singleQuadData...)
+	e, err := Explain(context.TODO(), store, &Visit{
+		From:       &Vertex{Values: []quad.Value{quad.IRI("alice")}},
+		Properties: PropertyPath{PropertyIRIString("likes")},
+	})
+	require.NoError(t, err)
+	// the traversal from the subject to the object through the predicate
+	require.Equal(t, "HasA(object)", e.Operation)
+	require.Equal(t, int64(1), e.Size)
+	ops := operations(*e)
+	require.Contains(t, ops, "MemStore(subject)")
+	require.Contains(t, ops, "MemStore(predicate)")
+}
@@ -0,0 +1,12 @@
+package linkedql
+
+import (
+	"github.com/cayleygraph/cayley/graph"
+	"github.com/cayleygraph/cayley/graph/federation"
+	"github.com/cayleygraph/cayley/query"
+)
+
+// BuildIteratorFederated builds the iterator of step against the union of stores.
+func BuildIteratorFederated(step IteratorStep, stores ...graph.QuadStore) (query.Iterator, error) {
+	return step.BuildIterator(federation.New(stores...))
+}
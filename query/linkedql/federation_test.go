@@ -0,0 +1,60 @@
+package linkedql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cayleygraph/cayley/graph/memstore"
+	"github.com/cayleygraph/quad"
+	"github.com/stretchr/testify/require"
+)
+
+func federatedResults(t *testing.T, step IteratorStep) []interface{} {
+	first := memstore.New(
+		quad.MakeIRI("alice", "likes", "bob", ""),
+		quad.MakeIRI("alice", "name", "Alice", ""),
+	)
+	second := memstore.New(
+		quad.MakeIRI("bob", "likes", "dan", ""),
+		quad.MakeIRI("bob", "name", "Bob", ""),
+		quad.MakeIRI("alice", "likes", "bob", ""),
+	)
+	it, err := BuildIteratorFederated(step, first, second)
+	require.NoError(t, err)
+	ctx := context.TODO()
+	var results []interface{}
+	for it.Next(ctx) {
+		results = append(results, it.Result())
+	}
+	require.NoError(t, it.Err())
+	require.NoError(t, it.Close())
+	return results
+}
+
+func TestFederateVisit(t *testing.T) {
+	results := federatedResults(t, &Visit{
+		From: &Visit{
+			From:       &Vertex{Values: []quad.Value{quad.IRI("alice")}},
+			Properties: PropertyPath{PropertyIRIString("likes")},
+		},
+		Properties: PropertyPath{PropertyIRIString("likes")},
+	})
+	require.Equal(t, []interface{}{map[string]string{"@id": "dan"}}, results)
+}
+
+func TestFederateHas(t *testing.T) {
+	results := federatedResults(t, &Has{
+		From:     &Vertex{},
+		Property: PropertyPath{PropertyIRIString("likes")},
+	})
+	// alice likes bob in both stores, the quad is only matched once.
+	require.Equal(t, []interface{}{
+		map[string]string{"@id": "alice"},
+		map[string]string{"@id": "bob"},
+	}, results)
+}
+
+func TestFederateVertex(t *testing.T) {
+	results := federatedResults(t, &Vertex{})
+	require.Len(t, results, 7)
+}
@@ -0,0 +1,259 @@
+package linkedql
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/cayleygraph/cayley/graph"
+	"github.com/cayleygraph/cayley/query/path"
+	"github.com/cayleygraph/quad"
+)
+
+// GraphPattern is a JSON-LD like description of the entities to match.
+// "@id" restricts the entities to the given identifiers. Any other key is a property
+// the entities must have with a value matching the given one:
+//
+//	a literal, as a JSON value or a JSON-LD value object, matches equal values.
+//	an object is a nested pattern the value must match. Without "@id" it matches any entity.
+//	an array matches values matching any of its items.
+//
+// "@reverse" holds properties linking other entities to the entities, with values
+// matched the same way. "@not" holds a pattern, or an array of patterns, the entities must not match.
+// Patterns have no variables, a nested pattern can not refer to the entity it is nested in.
+type GraphPattern map[string]interface{}
+
+// BuildPath returns the entities of from matching the pattern.
+func (g GraphPattern) BuildPath(qs graph.QuadStore, from *path.Path) (*path.Path, error) {
+	p := from
+	for _, key := range g.keys() {
+		v := g[key]
+		var err error
+		switch key {
+		case "@id":
+			var ids []quad.Value
+			ids, err = patternIdentifiers(v)
+			if err != nil {
+				return nil, err
+			}
+			p = p.Is(ids...)
+		case "@not":
+			p, err = patternExcept(qs, p, v)
+		case "@reverse":
+			m, ok := v.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("Expected an object for @reverse but received %v instead", v)
+			}
+			for _, rkey := range GraphPattern(m).keys() {
+				if strings.HasPrefix(rkey, "@") {
+					return nil, fmt.Errorf("unsupported pattern keyword %q in @reverse", rkey)
+				}
+				p, err = patternProperty(qs, p, quad.IRI(rkey), m[rkey], true)
+				if err != nil {
+					return nil, err
+				}
+			}
+		default:
+			if strings.HasPrefix(key, "@") {
+				return nil, fmt.Errorf("unsupported pattern keyword %q", key)
+			}
+			p, err = patternProperty(qs, p, quad.IRI(key), v, false)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// keys returns the keys of the pattern sorted, so the same pattern always builds the same path.
+func (g GraphPattern) keys() []string {
+	keys := make([]string, 0, len(g))
+	for k := range g {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// patternProperty returns the entities of p having property with a value matching v.
+// If reverse is set the property links the values to the entities instead.
+func patternProperty(qs graph.QuadStore, p *path.Path, property quad.IRI, v interface{}, reverse bool) (*path.Path, error) {
+	if values, ok := patternLiterals(v); ok {
+		if reverse {
+			return p.HasReverse(property, values...), nil
+		}
+		return p.Has(property, values...), nil
+	}
+	values, err := patternValuePath(qs, v)
+	if err != nil {
+		return nil, err
+	}
+	if reverse {
+		return p.And(values.Out(property)), nil
+	}
+	return p.And(values.In(property)), nil
+}
+
+// patternExcept returns the entities of p matching none of the patterns of v.
+func patternExcept(qs graph.QuadStore, p *path.Path, v interface{}) (*path.Path, error) {
+	items, ok := v.([]interface{})
+	if !ok {
+		items = []interface{}{v}
+	}
+	for _, item := range items {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("Expected an object for @not but received %v instead", item)
+		}
+		excluded, err := GraphPattern(m).BuildPath(qs, path.StartPath(qs))
+		if err != nil {
+			return nil, err
+		}
+		p = p.Except(excluded)
+	}
+	return p, nil
+}
+
+// patternValuePath returns the values matching the pattern value v.
+func patternValuePath(qs graph.QuadStore, v interface{}) (*path.Path, error) {
+	switch v := v.(type) {
+	case []interface{}:
+		var p *path.Path
+		for _, item := range v {
+			ip, err := patternValuePath(qs, item)
+			if err != nil {
+				return nil, err
+			}
+			if p == nil {
+				p = ip
+			} else {
+				p = p.Or(ip)
+			}
+		}
+		if p == nil {
+			return nil, fmt.Errorf("empty array in pattern")
+		}
+		return p, nil
+	case map[string]interface{}:
+		if _, ok := v["@value"]; !ok {
+			return GraphPattern(v).BuildPath(qs, path.StartPath(qs))
+		}
+	}
+	value, err := patternLiteral(v)
+	if err != nil {
+		return nil, err
+	}
+	return path.StartPath(qs, value), nil
+}
+
+// patternLiterals returns the literals of v if it only holds literals.
+func patternLiterals(v interface{}) ([]quad.Value, bool) {
+	items, ok := v.([]interface{})
+	if !ok {
+		items = []interface{}{v}
+	}
+	values := make([]quad.Value, 0, len(items))
+	for _, item := range items {
+		if m, ok := item.(map[string]interface{}); ok {
+			if _, ok := m["@value"]; !ok {
+				return nil, false
+			}
+		}
+		value, err := patternLiteral(item)
+		if err != nil {
+			return nil, false
+		}
+		values = append(values, value)
+	}
+	return values, len(values) != 0
+}
+
+// patternLiteral parses a literal of a pattern. Integral numbers are parsed as integers.
+func patternLiteral(v interface{}) (quad.Value, error) {
+	if f, ok := v.(float64); ok && f == math.Trunc(f) {
+		return quad.Int(f), nil
+	}
+	value, err := parseLiteral(v)
+	if err != nil {
+		return nil, err
+	}
+	// match the native values typed strings are stored as
+	if ts, ok := value.(quad.TypedString); ok {
+		if pv, err := ts.ParseValue(); err == nil {
+			return pv, nil
+		}
+	}
+	return value, nil
+}
+
+// patternIdentifiers parses the value of "@id" in a pattern.
+func patternIdentifiers(v interface{}) ([]quad.Value, error) {
+	items, ok := v.([]interface{})
+	if !ok {
+		items = []interface{}{v}
+	}
+	ids := make([]quad.Value, 0, len(items))
+	for _, item := range items {
+		s, ok := item.(string)
+		if !ok {
+			return nil, fmt.Errorf("Expected a string but received %v instead", item)
+		}
+		id, err := parseIdentifier(s)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// expandGraphPattern returns a copy of g with the IRIs of its properties and identifiers expanded.
+func expandGraphPattern(g GraphPattern, expand func(iri string) string) GraphPattern {
+	out := make(GraphPattern, len(g))
+	for k, v := range g {
+		if k == "@id" {
+			out[k] = expandPatternIdentifiers(v, expand)
+			continue
+		}
+		if !strings.HasPrefix(k, "@") {
+			k = expand(k)
+		}
+		out[k] = expandPatternValue(v, expand)
+	}
+	return out
+}
+
+func expandPatternValue(v interface{}, expand func(iri string) string) interface{} {
+	switch v := v.(type) {
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			out[i] = expandPatternValue(item, expand)
+		}
+		return out
+	case map[string]interface{}:
+		if _, ok := v["@value"]; !ok {
+			return map[string]interface{}(expandGraphPattern(v, expand))
+		}
+	}
+	return v
+}
+
+func expandPatternIdentifiers(v interface{}, expand func(iri string) string) interface{} {
+	switch v := v.(type) {
+	case string:
+		if strings.HasPrefix(v, "_:") {
+			return v
+		}
+		return expand(v)
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			out[i] = expandPatternIdentifiers(item, expand)
+		}
+		return out
+	}
+	return v
+}
@@ -0,0 +1,231 @@
+package linkedql
+
+import (
+	"context"
+	"sort"
+
+	"github.com/cayleygraph/cayley/graph"
+	"github.com/cayleygraph/cayley/graph/iterator"
+	"github.com/cayleygraph/cayley/graph/refs"
+	"github.com/cayleygraph/cayley/query/shape"
+)
+
+// joinsOptional reports whether s joins optional shapes to the intersection it is part of.
+func joinsOptional(s shape.Shape) bool {
+	switch s := s.(type) {
+	case shape.IntersectOpt, *shape.IntersectOpt:
+		return true
+	case shape.Intersect:
+		for _, sub := range s {
+			if joinsOptional(sub) {
+				return true
+			}
+		}
+	case shape.Save:
+		return joinsOptional(s.From)
+	}
+	return false
+}
+
+var _ shape.ValueFilter = intersectFilter{}
+
+// intersectFilter is a value filter ordering the intersection it is applied to.
+// The values are iterated from the first iterator of the intersection, so the results and
+// the number of times they appear do not change, and are checked against the other iterators
+// from the smallest to the largest, if their sizes can be estimated. Nothing is iterated if any
+// of the iterators is known to be empty.
+type intersectFilter struct{}
+
+func (intersectFilter) BuildIterator(qs graph.QuadStore, it iterator.Shape) iterator.Shape {
+	and, ok := it.(*iterator.And)
+	if !ok {
+		return it
+	}
+	return &intersectShape{subs: and.SubIterators()}
+}
+
+var _ iterator.Shape = (*intersectShape)(nil)
+
+// intersectShape is an intersection of subs, ordered once it is first advanced,
+// with the context of the caller.
+type intersectShape struct {
+	subs  []iterator.Shape
+	and   iterator.Shape
+	empty bool
+}
+
+// sizedShape is an iterator with its estimated size.
+type sizedShape struct {
+	shape iterator.Shape
+	size  int64
+}
+
+// build builds the intersection, if it was not built yet.
+// It reports whether one of the iterators is known to be empty.
+func (it *intersectShape) build(ctx context.Context) (empty bool) {
+	if it.and != nil || it.empty {
+		return it.empty
+	}
+	subs := make([]sizedShape, 0, len(it.subs))
+	sized := true
+	for _, sub := range it.subs {
+		st, err := sub.Stats(ctx)
+		if err != nil {
+			sized = false
+		} else if st.Size.Exact && st.Size.Value == 0 {
+			it.empty = true
+			return true
+		}
+		subs = append(subs, sizedShape{shape: sub, size: st.Size.Value})
+	}
+	if sized {
+		// the first iterator is the one iterated, keep it first
+		others := subs[1:]
+		sort.SliceStable(others, func(i, j int) bool {
+			return others[i].size < others[j].size
+		})
+		// sizes are rarely exact, check if the smallest iterator is empty before iterating a larger one
+		if len(others) != 0 && others[0].size < subs[0].size && isEmpty(ctx, others[0].shape) {
+			it.empty = true
+			return true
+		}
+	}
+	shapes := make([]iterator.Shape, 0, len(subs))
+	for _, sub := range subs {
+		shapes = append(shapes, sub.shape)
+	}
+	it.and = iterator.NewAnd(shapes...)
+	return false
+}
+
+// isEmpty reports whether it resolves to no value.
+func isEmpty(ctx context.Context, it iterator.Shape) bool {
+	sc := it.Iterate()
+	defer sc.Close()
+	return !sc.Next(ctx) && sc.Err() == nil
+}
+
+func (it *intersectShape) Iterate() iterator.Scanner {
+	return &intersectNext{shape: it}
+}
+
+func (it *intersectShape) Lookup() iterator.Index {
+	return &intersectContains{shape: it}
+}
+
+func (it *intersectShape) Stats(ctx context.Context) (iterator.Costs, error) {
+	return iterator.NewAnd(it.subs...).Stats(ctx)
+}
+
+func (it *intersectShape) Optimize(ctx context.Context) (iterator.Shape, bool) {
+	for i, sub := range it.subs {
+		if nsub, ok := sub.Optimize(ctx); ok {
+			it.subs[i] = nsub
+		}
+	}
+	return it, true
+}
+
+func (it *intersectShape) SubIterators() []iterator.Shape {
+	return it.subs
+}
+
+func (it *intersectShape) String() string {
+	return "Intersect"
+}
+
+type intersectNext struct {
+	shape *intersectShape
+	sub   iterator.Scanner
+}
+
+func (it *intersectNext) Next(ctx context.Context) bool {
+	if it.sub == nil {
+		if it.shape.build(ctx) {
+			return false
+		}
+		it.sub = it.shape.and.Iterate()
+	}
+	return it.sub.Next(ctx)
+}
+
+func (it *intersectNext) NextPath(ctx context.Context) bool {
+	return it.sub != nil && it.sub.NextPath(ctx)
+}
+
+func (it *intersectNext) TagResults(dst map[string]refs.Ref) {
+	if it.sub != nil {
+		it.sub.TagResults(dst)
+	}
+}
+
+func (it *intersectNext) Result() refs.Ref {
+	if it.sub == nil {
+		return nil
+	}
+	return it.sub.Result()
+}
+
+func (it *intersectNext) Err() error {
+	if it.sub == nil {
+		return nil
+	}
+	return it.sub.Err()
+}
+
+func (it *intersectNext) Close() error {
+	if it.sub == nil {
+		return nil
+	}
+	return it.sub.Close()
+}
+
+func (it *intersectNext) String() string { return "IntersectNext" }
+
+type intersectContains struct {
+	shape *intersectShape
+	sub   iterator.Index
+}
+
+func (it *intersectContains) Contains(ctx context.Context, ref refs.Ref) bool {
+	if it.sub == nil {
+		if it.shape.build(ctx) {
+			return false
+		}
+		it.sub = it.shape.and.Lookup()
+	}
+	return it.sub.Contains(ctx, ref)
+}
+
+func (it *intersectContains) NextPath(ctx context.Context) bool {
+	return it.sub != nil && it.sub.NextPath(ctx)
+}
+
+func (it *intersectContains) TagResults(dst map[string]refs.Ref) {
+	if it.sub != nil {
+		it.sub.TagResults(dst)
+	}
+}
+
+func (it *intersectContains) Result() refs.Ref {
+	if it.sub == nil {
+		return nil
+	}
+	return it.sub.Result()
+}
+
+func (it *intersectContains) Err() error {
+	if it.sub == nil {
+		return nil
+	}
+	return it.sub.Err()
+}
+
+func (it *intersectContains) Close() error {
+	if it.sub == nil {
+		return nil
+	}
+	return it.sub.Close()
+}
+
+func (it *intersectContains) String() string { return "IntersectContains" }
@@ -0,0 +1,75 @@
+package linkedql
+
+import (
+	"context"
+
+	"github.com/cayleygraph/cayley/query"
+	"github.com/cayleygraph/quad"
+	"github.com/cayleygraph/quad/jsonld"
+)
+
+var _ query.Iterator = (*AverageIterator)(nil)
+
+// AverageIterator is an iterator resolving to the average of the numeric values
+// of an underlying ValueIterator.
+type AverageIterator struct {
+	valueIt *ValueIterator
+	result  quad.Value
+	done    bool
+}
+
+// NewAverageIterator returns a new AverageIterator for a ValueIterator.
+func NewAverageIterator(valueIt *ValueIterator) *AverageIterator {
+	return &AverageIterator{valueIt: valueIt}
+}
+
+// Next implements query.Iterator.
+func (it *AverageIterator) Next(ctx context.Context) bool {
+	if it.done {
+		return false
+	}
+	it.done = true
+	var (
+		sum   float64
+		count int64
+	)
+	for it.valueIt.Next(ctx) {
+		switch v := it.valueIt.Value().(type) {
+		case quad.Int:
+			sum += float64(v)
+		case quad.Float:
+			sum += float64(v)
+		default:
+			continue
+		}
+		count++
+	}
+	if count == 0 || it.valueIt.Err() != nil {
+		return false
+	}
+	it.result = quad.Float(sum / float64(count))
+	return true
+}
+
+// Value returns the current value
+func (it *AverageIterator) Value() quad.Value {
+	return it.result
+}
+
+// Result implements query.Iterator.
+func (it *AverageIterator) Result() interface{} {
+	if it.result == nil {
+		return nil
+	}
+	return jsonld.FromValue(it.result)
+}
+
+// Err implements query.Iterator.
+func (it *AverageIterator) Err() error {
+	return it.valueIt.Err()
+}
+
+// Close implements query.Iterator.
+func (it *AverageIterator) Close() error {
+	return it.valueIt.Close()
+}
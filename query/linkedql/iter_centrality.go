@@ -0,0 +1,95 @@
+package linkedql
+
+import (
+	"context"
+
+	"github.com/cayleygraph/cayley/graph"
+	"github.com/cayleygraph/cayley/query"
+	"github.com/cayleygraph/cayley/query/path"
+	"github.com/cayleygraph/cayley/query/shape"
+	"github.com/cayleygraph/quad"
+	"github.com/cayleygraph/quad/jsonld"
+)
+
+var _ query.Iterator = (*CentralityIterator)(nil)
+
+// CentralityIterator is an iterator of documents of the distinct values of a ValueIterator
+// with their centrality score under "score".
+// The scores of all the nodes are computed before the first result.
+type CentralityIterator struct {
+	valueIt *ValueIterator
+	// scores computes the scores of the nodes by their string, nodes missing have a score of zero.
+	scores func(ctx context.Context) (map[string]int64, error)
+	byNode map[string]int64
+	seen   map[string]struct{}
+	result document
+	err    error
+}
+
+// NewDegreeCentralityIterator returns a new CentralityIterator scoring the values of valueIt
+// with the number of edges via links them to or from other nodes.
+// The degrees are counted with a single scan of the quads of the via properties.
+func NewDegreeCentralityIterator(qs graph.QuadStore, valueIt *ValueIterator, via *path.Path) *CentralityIterator {
+	scores := func(ctx context.Context) (map[string]int64, error) {
+		quads := shape.Quads{{Dir: quad.Predicate, Values: via.Shape()}}
+		sc := shape.BuildIterator(ctx, qs, quads).Iterate()
+		defer sc.Close()
+		degrees := make(map[string]int64)
+		for sc.Next(ctx) {
+			q := qs.Quad(sc.Result())
+			degrees[q.Subject.String()]++
+			degrees[q.Object.String()]++
+		}
+		return degrees, sc.Err()
+	}
+	return &CentralityIterator{valueIt: valueIt, scores: scores, seen: make(map[string]struct{})}
+}
+
+// Next implements query.Iterator.
+func (it *CentralityIterator) Next(ctx context.Context) bool {
+	it.result = nil
+	if it.err != nil {
+		return false
+	}
+	if it.byNode == nil {
+		it.byNode, it.err = it.scores(ctx)
+		if it.err != nil {
+			return false
+		}
+	}
+	for it.valueIt.Next(ctx) {
+		value := it.valueIt.Value()
+		if value == nil {
+			continue
+		}
+		if _, ok := it.seen[value.String()]; ok {
+			continue
+		}
+		it.seen[value.String()] = struct{}{}
+		it.result = newDocument(value, nil)
+		it.result["score"] = jsonld.FromValue(quad.Int(it.byNode[value.String()]))
+		return true
+	}
+	return false
+}
+
+// Result implements query.Iterator.
+func (it *CentralityIterator) Result() interface{} {
+	if it.result == nil {
+		return nil
+	}
+	return it.result
+}
+
+// Err implements query.Iterator.
+func (it *CentralityIterator) Err() error {
+	if it.err != nil {
+		return it.err
+	}
+	return it.valueIt.Err()
+}
+
+// Close implements query.Iterator.
+func (it *CentralityIterator) Close() error {
+	return it.valueIt.Close()
+}
@@ -0,0 +1,59 @@
+package linkedql
+
+import (
+	"context"
+
+	"github.com/cayleygraph/cayley/query"
+	"github.com/cayleygraph/quad"
+)
+
+var _ query.Iterator = (*ConstructIterator)(nil)
+
+// ConstructIterator is an iterator of quads built from the tags of the results of a TagsIterator.
+// Results missing any of the tags are skipped.
+type ConstructIterator struct {
+	tagsIt     *TagsIterator
+	subjectTag string
+	predicate  quad.Value
+	objectTag  string
+	result     *quad.Quad
+}
+
+// NewConstructIterator returns a new ConstructIterator of quads linking the values of subjectTag
+// to the values of objectTag with predicate.
+func NewConstructIterator(tagsIt *TagsIterator, subjectTag string, predicate quad.Value, objectTag string) *ConstructIterator {
+	return &ConstructIterator{tagsIt: tagsIt, subjectTag: subjectTag, predicate: predicate, objectTag: objectTag}
+}
+
+// Next implements query.Iterator.
+func (it *ConstructIterator) Next(ctx context.Context) bool {
+	it.result = nil
+	for it.tagsIt.Next(ctx) {
+		tags := it.tagsIt.getTagValues()
+		subject, object := tags[it.subjectTag], tags[it.objectTag]
+		if subject == nil || object == nil {
+			continue
+		}
+		it.result = &quad.Quad{Subject: subject, Predicate: it.predicate, Object: object}
+		return true
+	}
+	return false
+}
+
+// Result implements query.Iterator. It returns a quad.Quad.
+func (it *ConstructIterator) Result() interface{} {
+	if it.result == nil {
+		return nil
+	}
+	return *it.result
+}
+
+// Err implements query.Iterator.
+func (it *ConstructIterator) Err() error {
+	return it.tagsIt.Err()
+}
+
+// Close implements query.Iterator.
+func (it *ConstructIterator) Close() error {
+	return it.tagsIt.Close()
+}
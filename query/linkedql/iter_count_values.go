@@ -0,0 +1,65 @@
+package linkedql
+
+import (
+	"context"
+
+	"github.com/cayleygraph/cayley/query"
+	"github.com/cayleygraph/quad"
+)
+
+var _ query.Iterator = (*CountValuesIterator)(nil)
+
+// CountValuesIterator is an iterator of documents counting the occurrences of each distinct value of a ValueIterator.
+type CountValuesIterator struct {
+	valueIt *ValueIterator
+	values  []quad.Value
+	counts  map[string]int64
+	current int
+}
+
+// NewCountValuesIterator returns a new CountValuesIterator for a ValueIterator.
+func NewCountValuesIterator(valueIt *ValueIterator) *CountValuesIterator {
+	return &CountValuesIterator{valueIt: valueIt, current: -1}
+}
+
+// Next implements query.Iterator.
+func (it *CountValuesIterator) Next(ctx context.Context) bool {
+	if it.counts == nil {
+		it.counts = make(map[string]int64)
+		for it.valueIt.Next(ctx) {
+			value := it.valueIt.Value()
+			if value == nil {
+				continue
+			}
+			key := value.String()
+			if _, ok := it.counts[key]; !ok {
+				it.values = append(it.values, value)
+			}
+			it.counts[key]++
+		}
+	}
+	if it.current < len(it.values)-1 {
+		it.current++
+		return true
+	}
+	return false
+}
+
+// Result implements query.Iterator.
+func (it *CountValuesIterator) Result() interface{} {
+	if it.current < 0 || it.current >= len(it.values) {
+		return nil
+	}
+	value := it.values[it.current]
+	return newCountDocument(value, it.counts[value.String()])
+}
+
+// Err implements query.Iterator.
+func (it *CountValuesIterator) Err() error {
+	return it.valueIt.Err()
+}
+
+// Close implements query.Iterator.
+func (it *CountValuesIterator) Close() error {
+	return it.valueIt.Close()
+}
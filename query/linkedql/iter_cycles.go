@@ -0,0 +1,169 @@
+package linkedql
+
+import (
+	"context"
+	"sort"
+
+	"github.com/cayleygraph/cayley/graph"
+	"github.com/cayleygraph/cayley/query"
+	"github.com/cayleygraph/cayley/query/path"
+	"github.com/cayleygraph/quad"
+	"github.com/cayleygraph/quad/jsonld"
+)
+
+var _ query.Iterator = (*CyclesIterator)(nil)
+
+// CyclesIterator is an iterator of the nodes participating in a cycle reachable from the values
+// of a ValueIterator following the properties of via.
+type CyclesIterator struct {
+	qs      graph.QuadStore
+	fromIt  *ValueIterator
+	via     *path.Path
+	nodes   []quad.Value
+	current int
+	done    bool
+	err     error
+}
+
+// NewCyclesIterator returns a new CyclesIterator.
+func NewCyclesIterator(qs graph.QuadStore, fromIt *ValueIterator, via *path.Path) *CyclesIterator {
+	return &CyclesIterator{qs: qs, fromIt: fromIt, via: via, current: -1}
+}
+
+// Next implements query.Iterator.
+func (it *CyclesIterator) Next(ctx context.Context) bool {
+	if !it.done {
+		it.done = true
+		if it.err = it.search(ctx); it.err != nil {
+			return false
+		}
+	}
+	if it.current < len(it.nodes)-1 {
+		it.current++
+		return true
+	}
+	return false
+}
+
+// search finds the strongly connected components of the graph reachable from the values of fromIt
+// with Tarjan's algorithm. The nodes of the components with more than one node or with a loop are in a cycle.
+func (it *CyclesIterator) search(ctx context.Context) error {
+	s := &cycleSearch{
+		ctx:     ctx,
+		it:      it,
+		index:   make(map[string]int),
+		low:     make(map[string]int),
+		onStack: make(map[string]bool),
+	}
+	for it.fromIt.Next(ctx) {
+		value := it.fromIt.Value()
+		if value == nil {
+			continue
+		}
+		if _, ok := s.index[value.String()]; ok {
+			continue
+		}
+		if err := s.visit(value); err != nil {
+			return err
+		}
+	}
+	if err := it.fromIt.Err(); err != nil {
+		return err
+	}
+	sort.Slice(s.cyclic, func(i, j int) bool {
+		return lessValue(s.cyclic[i], s.cyclic[j])
+	})
+	it.nodes = s.cyclic
+	return nil
+}
+
+// neighbors returns the nodes node links to with the properties of via.
+func (it *CyclesIterator) neighbors(ctx context.Context, node quad.Value) ([]quad.Value, error) {
+	valueIt := NewValueIterator(path.StartPath(it.qs, node).Out(it.via), it.qs)
+	defer valueIt.Close()
+	var nodes []quad.Value
+	for valueIt.Next(ctx) {
+		if value := valueIt.Value(); value != nil {
+			nodes = append(nodes, value)
+		}
+	}
+	return nodes, valueIt.Err()
+}
+
+// cycleSearch is the state of a depth first search of cycles.
+type cycleSearch struct {
+	ctx     context.Context
+	it      *CyclesIterator
+	index   map[string]int
+	low     map[string]int
+	onStack map[string]bool
+	stack   []quad.Value
+	cyclic  []quad.Value
+}
+
+func (s *cycleSearch) visit(node quad.Value) error {
+	key := node.String()
+	s.index[key] = len(s.index)
+	s.low[key] = s.index[key]
+	s.stack = append(s.stack, node)
+	s.onStack[key] = true
+	next, err := s.it.neighbors(s.ctx, node)
+	if err != nil {
+		return err
+	}
+	loop := false
+	for _, n := range next {
+		nkey := n.String()
+		if nkey == key {
+			loop = true
+		}
+		if _, ok := s.index[nkey]; !ok {
+			if err := s.visit(n); err != nil {
+				return err
+			}
+			if s.low[nkey] < s.low[key] {
+				s.low[key] = s.low[nkey]
+			}
+		} else if s.onStack[nkey] && s.index[nkey] < s.low[key] {
+			s.low[key] = s.index[nkey]
+		}
+	}
+	if s.low[key] != s.index[key] {
+		return nil
+	}
+	// node is the root of a strongly connected component made of the nodes above it in the stack
+	i := len(s.stack) - 1
+	for s.stack[i].String() != key {
+		i--
+	}
+	component := s.stack[i:]
+	s.stack = s.stack[:i]
+	for _, n := range component {
+		s.onStack[n.String()] = false
+	}
+	if len(component) > 1 || loop {
+		s.cyclic = append(s.cyclic, component...)
+	}
+	return nil
+}
+
+// Result implements query.Iterator.
+func (it *CyclesIterator) Result() interface{} {
+	if it.current < 0 || it.current >= len(it.nodes) {
+		return nil
+	}
+	return jsonld.FromValue(it.nodes[it.current])
+}
+
+// Err implements query.Iterator.
+func (it *CyclesIterator) Err() error {
+	if it.err != nil {
+		return it.err
+	}
+	return it.fromIt.Err()
+}
+
+// Close implements query.Iterator.
+func (it *CyclesIterator) Close() error {
+	return it.fromIt.Close()
+}
@@ -0,0 +1,128 @@
+package linkedql
+
+import (
+	"context"
+
+	"github.com/cayleygraph/cayley/graph"
+	"github.com/cayleygraph/cayley/query"
+	"github.com/cayleygraph/quad"
+)
+
+var _ query.Iterator = (*DescribeIterator)(nil)
+
+// DescribeIterator is an iterator of documents of the distinct values of a ValueIterator
+// with all the quads they are the subject or the object of.
+type DescribeIterator struct {
+	qs       graph.QuadStore
+	valueIt  *ValueIterator
+	describe func(ctx context.Context, qs graph.QuadStore, node quad.Value) (document, error)
+	seen     map[string]struct{}
+	result   document
+	err      error
+}
+
+// NewDescribeIterator returns a new DescribeIterator for the values of valueIt.
+func NewDescribeIterator(qs graph.QuadStore, valueIt *ValueIterator) *DescribeIterator {
+	return &DescribeIterator{qs: qs, valueIt: valueIt, describe: describeDocument, seen: make(map[string]struct{})}
+}
+
+// NewAdjacencyIterator returns a new DescribeIterator for the values of valueIt
+// holding the outgoing and incoming edges of each value under "out" and "in".
+func NewAdjacencyIterator(qs graph.QuadStore, valueIt *ValueIterator) *DescribeIterator {
+	return &DescribeIterator{qs: qs, valueIt: valueIt, describe: adjacencyDocument, seen: make(map[string]struct{})}
+}
+
+// Next implements query.Iterator.
+func (it *DescribeIterator) Next(ctx context.Context) bool {
+	it.result = nil
+	if it.err != nil {
+		return false
+	}
+	for it.valueIt.Next(ctx) {
+		value := it.valueIt.Value()
+		if value == nil {
+			continue
+		}
+		if _, ok := it.seen[value.String()]; ok {
+			continue
+		}
+		it.seen[value.String()] = struct{}{}
+		it.result, it.err = it.describe(ctx, it.qs, value)
+		return it.err == nil
+	}
+	return false
+}
+
+// describeDocument returns the document of node with all its properties
+// and the properties linking other nodes to it under @reverse.
+func describeDocument(ctx context.Context, qs graph.QuadStore, node quad.Value) (document, error) {
+	ref := qs.ValueOf(node)
+	if ref == nil {
+		return newDocument(node, nil), nil
+	}
+	props, err := nodeProperties(ctx, qs, ref, quad.Subject)
+	if err != nil {
+		return nil, err
+	}
+	reverse, err := nodeProperties(ctx, qs, ref, quad.Object)
+	if err != nil {
+		return nil, err
+	}
+	d := newDocument(node, props)
+	if len(reverse) != 0 {
+		r := make(document, len(reverse))
+		for k, v := range reverse {
+			r[k] = v
+		}
+		d["@reverse"] = r
+	}
+	return d, nil
+}
+
+// adjacencyDocument returns the document of node with the properties of its
+// outgoing edges under "out" and of its incoming edges under "in".
+func adjacencyDocument(ctx context.Context, qs graph.QuadStore, node quad.Value) (document, error) {
+	ref := qs.ValueOf(node)
+	d := newDocument(node, nil)
+	for _, side := range []struct {
+		key string
+		dir quad.Direction
+	}{
+		{"out", quad.Subject},
+		{"in", quad.Object},
+	} {
+		edges := make(document)
+		if ref != nil {
+			props, err := nodeProperties(ctx, qs, ref, side.dir)
+			if err != nil {
+				return nil, err
+			}
+			for k, v := range props {
+				edges[k] = v
+			}
+		}
+		d[side.key] = edges
+	}
+	return d, nil
+}
+
+// Result implements query.Iterator.
+func (it *DescribeIterator) Result() interface{} {
+	if it.result == nil {
+		return nil
+	}
+	return it.result
+}
+
+// Err implements query.Iterator.
+func (it *DescribeIterator) Err() error {
+	if it.err != nil {
+		return it.err
+	}
+	return it.valueIt.Err()
+}
+
+// Close implements query.Iterator.
+func (it *DescribeIterator) Close() error {
+	return it.valueIt.Close()
+}
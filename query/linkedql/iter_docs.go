@@ -2,9 +2,17 @@ package linkedql
 
 import (
 	"context"
+	"encoding/json"
+	"io"
+	"sort"
+	"strings"
 
+	"github.com/cayleygraph/cayley/graph"
 	"github.com/cayleygraph/cayley/query"
+	"github.com/cayleygraph/cayley/query/shape"
 	"github.com/cayleygraph/quad"
+	"github.com/cayleygraph/quad/jsonld"
+	"github.com/cayleygraph/quad/voc"
 )
 
 var _ query.Iterator = (*DocumentIterator)(nil)
@@ -15,10 +23,25 @@ type idToProperties = map[quad.Value]properties
 
 // DocumentIterator is an iterator of documents from the graph
 type DocumentIterator struct {
+	// BatchSize is the number of entities whose outbound and inbound properties are read with
+	// a single scan of the quad store, once all the entities are known. The properties of each
+	// entity are read with a scan of their own if it is not set.
+	BatchSize  int
+	qs         graph.QuadStore
 	tagsIt     *TagsIterator
 	ids        []quad.Value
 	properties idToProperties
 	current    int
+	context    map[string]string
+	namespaces *voc.Namespaces
+	// outbound and inbound map the names of the properties read from the quad store to their tags.
+	outbound map[quad.Value]string
+	inbound  map[quad.Value]string
+	// reverse maps tags of inbound properties to their names.
+	reverse map[string]string
+	err     error
+	// genid prefixes the labels of blank nodes to skolemize them. Blank nodes are kept if empty.
+	genid string
 }
 
 // NewDocumentIterator returns a new DocumentIterator for a QuadStore and Path.
@@ -27,6 +50,29 @@ func NewDocumentIterator(valueIt *ValueIterator) *DocumentIterator {
 	return &DocumentIterator{tagsIt: tagsIt, current: -1}
 }
 
+// NewCompactDocumentIterator returns a new DocumentIterator emitting documents compacted with
+// a JSON-LD context mapping terms to IRIs.
+// The keys and identifiers of the documents starting with one of the IRIs use the term as a prefix instead.
+func NewCompactDocumentIterator(valueIt *ValueIterator, context map[string]string) *DocumentIterator {
+	it := NewDocumentIterator(valueIt)
+	if len(context) == 0 {
+		return it
+	}
+	it.context = context
+	it.namespaces = &voc.Namespaces{}
+	for term, iri := range context {
+		it.namespaces.Register(voc.Namespace{Prefix: term + ":", Full: iri})
+	}
+	return it
+}
+
+// Skolemize replaces the blank nodes of the documents with IRIs of the form
+// baseIRI/.well-known/genid/label. The IRIs are derived from the blank node labels so
+// the same blank node is always replaced with the same IRI.
+func (it *DocumentIterator) Skolemize(baseIRI string) {
+	it.genid = strings.TrimSuffix(baseIRI, "/") + "/.well-known/genid/"
+}
+
 // Next implements query.Iterator.
 func (it *DocumentIterator) Next(ctx context.Context) bool {
 	if it.properties == nil {
@@ -44,6 +90,11 @@ func (it *DocumentIterator) Next(ctx context.Context) bool {
 				m[k] = append(m[k], v)
 			}
 		}
+		if (len(it.outbound) != 0 || len(it.inbound) != 0) && it.tagsIt.Err() == nil {
+			if it.err = it.readProperties(ctx); it.err != nil {
+				return false
+			}
+		}
 	}
 	if it.current < len(it.ids)-1 {
 		it.current++
@@ -52,12 +103,197 @@ func (it *DocumentIterator) Next(ctx context.Context) bool {
 	return false
 }
 
+// readProperties adds the values of the outbound properties and the entities linking to each entity
+// with the inbound properties to its properties, reading the quads of BatchSize entities at once.
+// Unlike the tags of a path, which hold a single value, all the values of a property are listed.
+func (it *DocumentIterator) readProperties(ctx context.Context) error {
+	size := it.BatchSize
+	if size <= 0 {
+		size = 1
+	}
+	var ids []quad.Value
+	seen := make(map[quad.Value]struct{}, len(it.ids))
+	for _, id := range it.ids {
+		if _, ok := seen[id]; !ok && id != nil {
+			seen[id] = struct{}{}
+			ids = append(ids, id)
+		}
+	}
+	for start := 0; start < len(ids); start += size {
+		end := start + size
+		if end > len(ids) {
+			end = len(ids)
+		}
+		if err := it.addProperties(ctx, quad.Subject, ids[start:end], it.outbound); err != nil {
+			return err
+		}
+		if err := it.addProperties(ctx, quad.Object, ids[start:end], it.inbound); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addProperties adds the quads in direction dir of the ids with the predicates of tags to the properties
+// of the ids, under the tags of their predicates. The values of each property are sorted, so they do not
+// depend on the entities read together.
+func (it *DocumentIterator) addProperties(ctx context.Context, dir quad.Direction, ids []quad.Value, tags map[quad.Value]string) error {
+	if len(tags) == 0 {
+		return nil
+	}
+	names := make([]quad.Value, 0, len(tags))
+	for name := range tags {
+		names = append(names, name)
+	}
+	other := quad.Object
+	if dir == quad.Object {
+		other = quad.Subject
+	}
+	quads := shape.Quads{
+		{Dir: dir, Values: shape.Lookup(ids)},
+		{Dir: quad.Predicate, Values: shape.Lookup(names)},
+	}
+	values := make(map[quad.Value]map[string][]quad.Value)
+	sc := shape.BuildIterator(ctx, it.qs, quads).Iterate()
+	defer sc.Close()
+	for sc.Next(ctx) {
+		q := it.qs.Quad(sc.Result())
+		id := q.Get(dir)
+		m, ok := values[id]
+		if !ok {
+			m = make(map[string][]quad.Value)
+			values[id] = m
+		}
+		tag := tags[q.Predicate]
+		m[tag] = append(m[tag], q.Get(other))
+	}
+	if err := sc.Err(); err != nil {
+		return err
+	}
+	for id, m := range values {
+		props, ok := it.properties[id]
+		if !ok {
+			props = make(properties)
+			it.properties[id] = props
+		}
+		for tag, vals := range m {
+			sort.Slice(vals, func(i, j int) bool {
+				return vals[i].String() < vals[j].String()
+			})
+			for _, v := range vals {
+				props[tag] = append(props[tag], jsonld.FromValue(v))
+			}
+		}
+	}
+	return nil
+}
+
 // Result implements query.Iterator.
 func (it *DocumentIterator) Result() interface{} {
 	if it.current >= len(it.ids) {
 		return nil
 	}
 	id := it.ids[it.current]
+	d := newDocument(id, it.properties[id])
+	if len(it.reverse) != 0 {
+		reverse := make(document)
+		for tag, name := range it.reverse {
+			if v, ok := d[tag]; ok {
+				reverse[name] = v
+				delete(d, tag)
+			}
+		}
+		if len(reverse) != 0 {
+			d["@reverse"] = reverse
+		}
+	}
+	if it.genid != "" {
+		d = it.skolemize(d).(document)
+	}
+	if it.namespaces == nil {
+		return d
+	}
+	compacted := it.compact(d).(document)
+	compacted["@context"] = it.context
+	return compacted
+}
+
+// compact replaces the IRIs of the keys and identifiers in v with their compact form.
+func (it *DocumentIterator) compact(v interface{}) interface{} {
+	switch v := v.(type) {
+	case document:
+		c := make(document, len(v))
+		for k, val := range v {
+			if k == "@id" {
+				if id, ok := val.(string); ok {
+					c[k] = it.namespaces.ShortIRI(id)
+					continue
+				}
+			}
+			c[it.namespaces.ShortIRI(k)] = it.compact(val)
+		}
+		return c
+	case map[string]string:
+		c := make(map[string]string, len(v))
+		for k, val := range v {
+			if k == "@id" || k == "@type" {
+				val = it.namespaces.ShortIRI(val)
+			}
+			c[k] = val
+		}
+		return c
+	case []interface{}:
+		c := make([]interface{}, len(v))
+		for i, val := range v {
+			c[i] = it.compact(val)
+		}
+		return c
+	}
+	return v
+}
+
+// skolemize replaces the blank node identifiers in v with IRIs.
+func (it *DocumentIterator) skolemize(v interface{}) interface{} {
+	switch v := v.(type) {
+	case document:
+		c := make(document, len(v))
+		for k, val := range v {
+			if id, ok := val.(string); ok && k == "@id" {
+				c[k] = it.skolemIRI(id)
+				continue
+			}
+			c[k] = it.skolemize(val)
+		}
+		return c
+	case map[string]string:
+		c := make(map[string]string, len(v))
+		for k, val := range v {
+			if k == "@id" {
+				val = it.skolemIRI(val)
+			}
+			c[k] = val
+		}
+		return c
+	case []interface{}:
+		c := make([]interface{}, len(v))
+		for i, val := range v {
+			c[i] = it.skolemize(val)
+		}
+		return c
+	}
+	return v
+}
+
+// skolemIRI returns the IRI replacing id if it is a blank node identifier.
+func (it *DocumentIterator) skolemIRI(id string) string {
+	if !strings.HasPrefix(id, "_:") {
+		return id
+	}
+	return it.genid + id[2:]
+}
+
+// newDocument returns a document of an entity and its properties.
+func newDocument(id quad.Value, props properties) document {
 	// FIXME(iddan): don't cast to string when collation is Raw
 	var sid string
 	switch val := id.(type) {
@@ -69,14 +305,49 @@ func (it *DocumentIterator) Result() interface{} {
 	d := document{
 		"@id": sid,
 	}
-	for k, v := range it.properties[id] {
+	for k, v := range props {
 		d[k] = v
 	}
 	return d
 }
 
+// WriteNDJSON writes the documents to w as newline-delimited JSON, one document per line.
+// If w has a Flush method it is called after every document.
+func (it *DocumentIterator) WriteNDJSON(ctx context.Context, w io.Writer) error {
+	enc := json.NewEncoder(w)
+	for it.Next(ctx) {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := enc.Encode(it.Result()); err != nil {
+			return err
+		}
+		if err := flush(w); err != nil {
+			return err
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return it.Err()
+}
+
+// flush flushes buffered writers such as bufio.Writer or http.ResponseWriter.
+func flush(w io.Writer) error {
+	switch f := w.(type) {
+	case interface{ Flush() error }:
+		return f.Flush()
+	case interface{ Flush() }:
+		f.Flush()
+	}
+	return nil
+}
+
 // Err implements query.Iterator.
 func (it *DocumentIterator) Err() error {
+	if it.err != nil {
+		return it.err
+	}
 	if it.tagsIt == nil {
 		return nil
 	}
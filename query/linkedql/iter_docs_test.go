@@ -0,0 +1,121 @@
+package linkedql
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/cayleygraph/cayley/graph/memstore"
+	"github.com/cayleygraph/quad"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestDocumentIterator(t testing.TB, data []quad.Quad) *DocumentIterator {
+	store := memstore.New(data...)
+	step := &Documents{From: &Properties{From: &Vertex{}, Names: []quad.IRI{"likes"}}}
+	it, err := step.BuildIterator(store)
+	require.NoError(t, err)
+	return it.(*DocumentIterator)
+}
+
+func TestDocumentIteratorWriteNDJSON(t *testing.T) {
+	data := []quad.Quad{
+		quad.MakeIRI("alice", "likes", "bob", ""),
+		quad.MakeIRI("bob", "likes", "dan", ""),
+	}
+	var buf bytes.Buffer
+	w := bufio.NewWriter(&buf)
+	err := newTestDocumentIterator(t, data).WriteNDJSON(context.TODO(), w)
+	require.NoError(t, err)
+
+	var ids []string
+	sc := bufio.NewScanner(&buf)
+	for sc.Scan() {
+		var doc map[string]interface{}
+		require.NoError(t, json.Unmarshal(sc.Bytes(), &doc))
+		ids = append(ids, doc["@id"].(string))
+	}
+	require.NoError(t, sc.Err())
+	require.ElementsMatch(t, []string{"alice", "bob"}, ids)
+}
+
+func TestDocumentIteratorWriteNDJSONCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+	var buf bytes.Buffer
+	err := newTestDocumentIterator(t, singleQuadData).WriteNDJSON(ctx, &buf)
+	require.Equal(t, context.Canceled, err)
+	require.Zero(t, buf.Len())
+}
+
+// documentsData returns n nodes linking to the two next nodes, so most nodes have several
+// outbound and inbound links.
+func documentsData(n int) []quad.Quad {
+	var data []quad.Quad
+	for i := 0; i < n; i++ {
+		node := quad.IRI(fmt.Sprintf("node%d", i))
+		data = append(data,
+			quad.Make(node, quad.IRI("name"), quad.String(fmt.Sprintf("Node %d", i)), nil),
+			quad.Make(node, quad.IRI("next"), quad.IRI(fmt.Sprintf("node%d", i+1)), nil),
+			quad.Make(node, quad.IRI("next"), quad.IRI(fmt.Sprintf("node%d", i+2)), nil),
+		)
+	}
+	return data
+}
+
+func readDocuments(t testing.TB, store *memstore.QuadStore, batchSize int) []interface{} {
+	step := &Documents{
+		From:         &Properties{From: &Vertex{}, Names: []quad.IRI{"name", "next"}},
+		ReverseNames: []quad.IRI{"next"},
+		BatchSize:    batchSize,
+	}
+	it, err := step.BuildIterator(store)
+	require.NoError(t, err)
+	ctx := context.TODO()
+	var results []interface{}
+	for it.Next(ctx) {
+		results = append(results, it.Result())
+	}
+	require.NoError(t, it.Err())
+	require.NoError(t, it.Close())
+	return results
+}
+
+func TestDocumentIteratorBatch(t *testing.T) {
+	store := memstore.New(documentsData(25)...)
+	expected := readDocuments(t, store, 0)
+	require.Len(t, expected, 25)
+	require.Equal(t, map[string]interface{}{
+		"@id":  "node2",
+		"name": []interface{}{"Node 2"},
+		"next": []interface{}{
+			map[string]string{"@id": "node3"},
+			map[string]string{"@id": "node4"},
+		},
+		"@reverse": map[string]interface{}{
+			"next": []interface{}{
+				map[string]string{"@id": "node0"},
+				map[string]string{"@id": "node1"},
+			},
+		},
+	}, expected[2])
+	for _, size := range []int{1, 10, 25, 100} {
+		t.Run(fmt.Sprint(size), func(t *testing.T) {
+			require.Equal(t, expected, readDocuments(t, store, size))
+		})
+	}
+}
+
+func BenchmarkDocuments(b *testing.B) {
+	store := memstore.New(documentsData(500)...)
+	for _, size := range []int{0, 100} {
+		b.Run(fmt.Sprintf("batch%d", size), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				readDocuments(b, store, size)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package linkedql
+
+import (
+	"context"
+
+	"github.com/cayleygraph/cayley/query"
+	"github.com/cayleygraph/quad"
+	"github.com/cayleygraph/quad/jsonld"
+)
+
+var _ query.Iterator = (*ExistsIterator)(nil)
+
+// ExistsIterator is an iterator resolving to a single boolean reporting
+// whether the underlying ValueIterator has any result.
+type ExistsIterator struct {
+	valueIt *ValueIterator
+	result  quad.Value
+	done    bool
+}
+
+// NewExistsIterator returns a new ExistsIterator for a ValueIterator.
+func NewExistsIterator(valueIt *ValueIterator) *ExistsIterator {
+	return &ExistsIterator{valueIt: valueIt}
+}
+
+// Next implements query.Iterator.
+func (it *ExistsIterator) Next(ctx context.Context) bool {
+	if it.done {
+		return false
+	}
+	it.done = true
+	exists := it.valueIt.Next(ctx)
+	if it.valueIt.Err() != nil {
+		return false
+	}
+	it.result = quad.Bool(exists)
+	return true
+}
+
+// Value returns the current value
+func (it *ExistsIterator) Value() quad.Value {
+	return it.result
+}
+
+// Result implements query.Iterator.
+func (it *ExistsIterator) Result() interface{} {
+	if it.result == nil {
+		return nil
+	}
+	return jsonld.FromValue(it.result)
+}
+
+// Err implements query.Iterator.
+func (it *ExistsIterator) Err() error {
+	return it.valueIt.Err()
+}
+
+// Close implements query.Iterator.
+func (it *ExistsIterator) Close() error {
+	return it.valueIt.Close()
+}
@@ -0,0 +1,113 @@
+package linkedql
+
+import (
+	"context"
+	"strings"
+
+	"github.com/cayleygraph/cayley/graph"
+	"github.com/cayleygraph/cayley/query"
+)
+
+var _ query.Iterator = (*FrameIterator)(nil)
+
+// FrameIterator is an iterator of the documents of a DocumentIterator shaped by a JSON-LD frame.
+// For every property of the frame with an object value, entities referenced by the property
+// are embedded in the document, framed by that object.
+// If the frame has "@explicit" set to true only the properties of the frame are kept.
+type FrameIterator struct {
+	qs     graph.QuadStore
+	docIt  *DocumentIterator
+	frame  map[string]interface{}
+	result document
+	err    error
+}
+
+// NewFrameIterator returns a new FrameIterator of the documents of docIt.
+func NewFrameIterator(qs graph.QuadStore, docIt *DocumentIterator, frame map[string]interface{}) *FrameIterator {
+	return &FrameIterator{qs: qs, docIt: docIt, frame: frame}
+}
+
+// Next implements query.Iterator.
+func (it *FrameIterator) Next(ctx context.Context) bool {
+	it.result = nil
+	if it.err != nil || !it.docIt.Next(ctx) {
+		return false
+	}
+	d, ok := it.docIt.Result().(document)
+	if !ok {
+		return false
+	}
+	it.result, it.err = it.applyFrame(ctx, d, it.frame)
+	return it.err == nil
+}
+
+// applyFrame returns the document d shaped by frame.
+func (it *FrameIterator) applyFrame(ctx context.Context, d document, frame map[string]interface{}) (document, error) {
+	explicit, _ := frame["@explicit"].(bool)
+	out := make(document, len(d))
+	for k, v := range d {
+		if _, ok := frame[k]; explicit && !ok && !strings.HasPrefix(k, "@") {
+			continue
+		}
+		out[k] = v
+	}
+	for k, v := range frame {
+		subFrame, ok := v.(map[string]interface{})
+		if !ok || strings.HasPrefix(k, "@") {
+			continue
+		}
+		values, ok := out[k].([]interface{})
+		if !ok {
+			continue
+		}
+		embedded := make([]interface{}, 0, len(values))
+		for _, value := range values {
+			ref, ok := value.(map[string]string)
+			if !ok || ref["@id"] == "" {
+				embedded = append(embedded, value)
+				continue
+			}
+			node, err := it.node(ctx, ref["@id"])
+			if err != nil {
+				return nil, err
+			}
+			framed, err := it.applyFrame(ctx, node, subFrame)
+			if err != nil {
+				return nil, err
+			}
+			embedded = append(embedded, framed)
+		}
+		out[k] = embedded
+	}
+	return out, nil
+}
+
+// node returns the document of the entity identified by id with all its properties.
+func (it *FrameIterator) node(ctx context.Context, id string) (document, error) {
+	value, err := parseIdentifier(id)
+	if err != nil {
+		return nil, err
+	}
+	return nodeDocument(ctx, it.qs, value)
+}
+
+// Result implements query.Iterator.
+func (it *FrameIterator) Result() interface{} {
+	if it.result == nil {
+		return nil
+	}
+	return it.result
+}
+
+// Err implements query.Iterator.
+func (it *FrameIterator) Err() error {
+	if it.err != nil {
+		return it.err
+	}
+	return it.docIt.Err()
+}
+
+// Close implements query.Iterator.
+func (it *FrameIterator) Close() error {
+	return it.docIt.Close()
+}
@@ -0,0 +1,94 @@
+package linkedql
+
+import (
+	"context"
+
+	"github.com/cayleygraph/cayley/query"
+	"github.com/cayleygraph/quad"
+)
+
+var _ query.Iterator = (*GroupByIterator)(nil)
+
+type groupByGroup struct {
+	key        quad.Value
+	ids        []quad.Value
+	properties idToProperties
+}
+
+// GroupByIterator is an iterator of documents grouping the results of a
+// TagsIterator by the value of a tag. Each group lists the documents of its members.
+type GroupByIterator struct {
+	tagsIt  *TagsIterator
+	tag     string
+	groups  []*groupByGroup
+	current int
+}
+
+// NewGroupByIterator returns a new GroupByIterator grouping the results of tagsIt by tag.
+func NewGroupByIterator(tagsIt *TagsIterator, tag string) *GroupByIterator {
+	return &GroupByIterator{tagsIt: tagsIt, tag: tag, current: -1}
+}
+
+// Next implements query.Iterator.
+func (it *GroupByIterator) Next(ctx context.Context) bool {
+	if it.groups == nil {
+		it.groups = []*groupByGroup{}
+		byKey := make(map[string]*groupByGroup)
+		for it.tagsIt.Next(ctx) {
+			key := it.tagsIt.getTagValues()[it.tag]
+			if key == nil {
+				continue
+			}
+			group, ok := byKey[key.String()]
+			if !ok {
+				group = &groupByGroup{key: key, properties: make(idToProperties)}
+				byKey[key.String()] = group
+				it.groups = append(it.groups, group)
+			}
+			id := it.tagsIt.valueIt.Value()
+			m, ok := group.properties[id]
+			if !ok {
+				m = make(properties)
+				group.properties[id] = m
+				group.ids = append(group.ids, id)
+			}
+			for k, v := range it.tagsIt.getTags() {
+				if k == it.tag {
+					continue
+				}
+				m[k] = append(m[k], v)
+			}
+		}
+	}
+	if it.current < len(it.groups)-1 {
+		it.current++
+		return true
+	}
+	return false
+}
+
+// Result implements query.Iterator.
+func (it *GroupByIterator) Result() interface{} {
+	if it.current < 0 || it.current >= len(it.groups) {
+		return nil
+	}
+	group := it.groups[it.current]
+	members := make([]interface{}, 0, len(group.ids))
+	for _, id := range group.ids {
+		members = append(members, newDocument(id, group.properties[id]))
+	}
+	return document{
+		"@id":     valueID(group.key),
+		"members": members,
+	}
+}
+
+// Err implements query.Iterator.
+func (it *GroupByIterator) Err() error {
+	return it.tagsIt.Err()
+}
+
+// Close implements query.Iterator.
+func (it *GroupByIterator) Close() error {
+	return it.tagsIt.Close()
+}
@@ -0,0 +1,88 @@
+package linkedql
+
+import (
+	"context"
+
+	"github.com/cayleygraph/cayley/query"
+	"github.com/cayleygraph/quad"
+	"github.com/cayleygraph/quad/jsonld"
+)
+
+var _ query.Iterator = (*GroupCountIterator)(nil)
+
+// GroupCountIterator is an iterator of documents counting the results of a
+// TagsIterator for each distinct value of a tag.
+type GroupCountIterator struct {
+	tagsIt  *TagsIterator
+	tag     string
+	keys    []quad.Value
+	counts  map[string]int64
+	current int
+}
+
+// NewGroupCountIterator returns a new GroupCountIterator grouping the results of tagsIt by tag.
+func NewGroupCountIterator(tagsIt *TagsIterator, tag string) *GroupCountIterator {
+	return &GroupCountIterator{tagsIt: tagsIt, tag: tag, current: -1}
+}
+
+// Next implements query.Iterator.
+func (it *GroupCountIterator) Next(ctx context.Context) bool {
+	if it.counts == nil {
+		it.counts = make(map[string]int64)
+		for it.tagsIt.Next(ctx) {
+			value := it.tagsIt.getTagValues()[it.tag]
+			if value == nil {
+				continue
+			}
+			// group by the serialized form so blank nodes and typed literals are kept apart
+			key := value.String()
+			if _, ok := it.counts[key]; !ok {
+				it.keys = append(it.keys, value)
+			}
+			it.counts[key]++
+		}
+	}
+	if it.current < len(it.keys)-1 {
+		it.current++
+		return true
+	}
+	return false
+}
+
+// Result implements query.Iterator.
+func (it *GroupCountIterator) Result() interface{} {
+	if it.current < 0 || it.current >= len(it.keys) {
+		return nil
+	}
+	value := it.keys[it.current]
+	return newCountDocument(value, it.counts[value.String()])
+}
+
+// newCountDocument returns a document of a value and the number of times it was counted.
+func newCountDocument(value quad.Value, count int64) document {
+	return document{
+		"@id":   valueID(value),
+		"count": count,
+	}
+}
+
+// valueID returns the @id of a document about value.
+func valueID(value quad.Value) interface{} {
+	switch val := value.(type) {
+	case quad.IRI:
+		return string(val)
+	case quad.BNode:
+		return val.String()
+	}
+	return jsonld.FromValue(value)
+}
+
+// Err implements query.Iterator.
+func (it *GroupCountIterator) Err() error {
+	return it.tagsIt.Err()
+}
+
+// Close implements query.Iterator.
+func (it *GroupCountIterator) Close() error {
+	return it.tagsIt.Close()
+}
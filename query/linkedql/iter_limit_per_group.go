@@ -0,0 +1,60 @@
+package linkedql
+
+import (
+	"context"
+
+	"github.com/cayleygraph/cayley/query"
+	"github.com/cayleygraph/quad"
+)
+
+var _ query.Iterator = (*LimitPerGroupIterator)(nil)
+
+// LimitPerGroupIterator is an iterator of values of a TagsIterator skipping values once limit values sharing the same value of a tag were returned.
+// Values missing the tag are never skipped. Only a counter is kept per distinct value of the tag.
+type LimitPerGroupIterator struct {
+	tagsIt *TagsIterator
+	tag    string
+	limit  int
+	counts map[string]int
+}
+
+// NewLimitPerGroupIterator returns a new LimitPerGroupIterator returning up to limit values of tagsIt per value of tag.
+func NewLimitPerGroupIterator(tagsIt *TagsIterator, tag string, limit int) *LimitPerGroupIterator {
+	return &LimitPerGroupIterator{tagsIt: tagsIt, tag: tag, limit: limit, counts: make(map[string]int)}
+}
+
+// Next implements query.Iterator.
+func (it *LimitPerGroupIterator) Next(ctx context.Context) bool {
+	for it.tagsIt.Next(ctx) {
+		key := it.tagsIt.getTagValues()[it.tag]
+		if key == nil {
+			return true
+		}
+		if it.counts[key.String()] >= it.limit {
+			continue
+		}
+		it.counts[key.String()]++
+		return true
+	}
+	return false
+}
+
+// Value returns the current value
+func (it *LimitPerGroupIterator) Value() quad.Value {
+	return it.tagsIt.valueIt.Value()
+}
+
+// Result implements query.Iterator.
+func (it *LimitPerGroupIterator) Result() interface{} {
+	return it.tagsIt.valueIt.Result()
+}
+
+// Err implements query.Iterator.
+func (it *LimitPerGroupIterator) Err() error {
+	return it.tagsIt.Err()
+}
+
+// Close implements query.Iterator.
+func (it *LimitPerGroupIterator) Close() error {
+	return it.tagsIt.Close()
+}
@@ -0,0 +1,109 @@
+package linkedql
+
+import (
+	"context"
+	"sort"
+
+	"github.com/cayleygraph/cayley/query"
+	"github.com/cayleygraph/quad"
+	"github.com/cayleygraph/quad/jsonld"
+)
+
+var _ query.Iterator = (*OrderByIterator)(nil)
+
+type orderByEntry struct {
+	id  quad.Value
+	key quad.Value
+}
+
+// OrderByIterator is an iterator of values of a TagsIterator ordered by the value of a tag.
+// Values missing the tag are ordered last.
+type OrderByIterator struct {
+	tagsIt     *TagsIterator
+	tag        string
+	descending bool
+	entries    []orderByEntry
+	current    int
+}
+
+// NewOrderByIterator returns a new OrderByIterator ordering the values of tagsIt by tag.
+func NewOrderByIterator(tagsIt *TagsIterator, tag string, descending bool) *OrderByIterator {
+	return &OrderByIterator{tagsIt: tagsIt, tag: tag, descending: descending, current: -1}
+}
+
+// Next implements query.Iterator.
+func (it *OrderByIterator) Next(ctx context.Context) bool {
+	if it.entries == nil {
+		it.entries = []orderByEntry{}
+		seen := make(map[string]int)
+		for it.tagsIt.Next(ctx) {
+			id := it.tagsIt.valueIt.Value()
+			key := it.tagsIt.getTagValues()[it.tag]
+			i, ok := seen[id.String()]
+			if !ok {
+				seen[id.String()] = len(it.entries)
+				it.entries = append(it.entries, orderByEntry{id: id, key: key})
+				continue
+			}
+			// keep the key sorting first for entities with multiple values
+			entry := &it.entries[i]
+			if entry.key == nil || (key != nil && it.less(key, entry.key)) {
+				entry.key = key
+			}
+		}
+		sort.SliceStable(it.entries, func(i, j int) bool {
+			a, b := it.entries[i], it.entries[j]
+			switch {
+			case a.key == nil && b.key == nil:
+				return a.id.String() < b.id.String()
+			case a.key == nil:
+				return false
+			case b.key == nil:
+				return true
+			}
+			if c := compareValues(a.key, b.key); c != 0 {
+				return it.less(a.key, b.key)
+			}
+			return a.id.String() < b.id.String()
+		})
+	}
+	if it.current < len(it.entries)-1 {
+		it.current++
+		return true
+	}
+	return false
+}
+
+func (it *OrderByIterator) less(a, b quad.Value) bool {
+	if it.descending {
+		return compareValues(a, b) > 0
+	}
+	return compareValues(a, b) < 0
+}
+
+// Value returns the current value
+func (it *OrderByIterator) Value() quad.Value {
+	if it.current < 0 || it.current >= len(it.entries) {
+		return nil
+	}
+	return it.entries[it.current].id
+}
+
+// Result implements query.Iterator.
+func (it *OrderByIterator) Result() interface{} {
+	value := it.Value()
+	if value == nil {
+		return nil
+	}
+	return jsonld.FromValue(value)
+}
+
+// Err implements query.Iterator.
+func (it *OrderByIterator) Err() error {
+	return it.tagsIt.Err()
+}
+
+// Close implements query.Iterator.
+func (it *OrderByIterator) Close() error {
+	return it.tagsIt.Close()
+}
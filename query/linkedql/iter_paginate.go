@@ -0,0 +1,154 @@
+package linkedql
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/cayleygraph/cayley/query"
+	"github.com/cayleygraph/quad"
+	"github.com/cayleygraph/quad/jsonld"
+)
+
+var _ query.Iterator = (*PaginateIterator)(nil)
+
+// PaginateIterator is an iterator emitting a single page of the distinct values of a ValueIterator.
+// Values are ordered so a page can be resumed from the last value of the previous page.
+type PaginateIterator struct {
+	valueIt  *ValueIterator
+	pageSize int
+	after    quad.Value
+	page     []quad.Value
+	next     string
+	done     bool
+	err      error
+}
+
+// NewPaginateIterator returns a new PaginateIterator emitting up to pageSize values following the cursor.
+// An empty cursor starts at the first page.
+func NewPaginateIterator(valueIt *ValueIterator, pageSize int, cursor string) (*PaginateIterator, error) {
+	if pageSize <= 0 {
+		return nil, fmt.Errorf("page size must be positive, got %d", pageSize)
+	}
+	after, err := decodeCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+	return &PaginateIterator{valueIt: valueIt, pageSize: pageSize, after: after}, nil
+}
+
+func lessValue(a, b quad.Value) bool {
+	if c := compareValues(a, b); c != 0 {
+		return c < 0
+	}
+	return a.String() < b.String()
+}
+
+// Next implements query.Iterator.
+func (it *PaginateIterator) Next(ctx context.Context) bool {
+	if it.done {
+		return false
+	}
+	it.done = true
+	seen := make(map[string]struct{})
+	var values []quad.Value
+	for it.valueIt.Next(ctx) {
+		value := it.valueIt.Value()
+		if value == nil {
+			continue
+		}
+		if it.after != nil && !lessValue(it.after, value) {
+			continue
+		}
+		if _, ok := seen[value.String()]; ok {
+			continue
+		}
+		seen[value.String()] = struct{}{}
+		values = append(values, value)
+	}
+	if it.valueIt.Err() != nil {
+		return false
+	}
+	sort.Slice(values, func(i, j int) bool {
+		return lessValue(values[i], values[j])
+	})
+	if len(values) > it.pageSize {
+		values = values[:it.pageSize]
+		next, err := encodeCursor(values[len(values)-1])
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.next = next
+	}
+	it.page = values
+	return true
+}
+
+// Result implements query.Iterator.
+func (it *PaginateIterator) Result() interface{} {
+	if !it.done {
+		return nil
+	}
+	results := make([]interface{}, 0, len(it.page))
+	for _, value := range it.page {
+		results = append(results, jsonld.FromValue(value))
+	}
+	d := document{
+		"results": results,
+	}
+	if it.next != "" {
+		d["nextCursor"] = it.next
+	}
+	return d
+}
+
+// Err implements query.Iterator.
+func (it *PaginateIterator) Err() error {
+	if it.err != nil {
+		return it.err
+	}
+	return it.valueIt.Err()
+}
+
+// Close implements query.Iterator.
+func (it *PaginateIterator) Close() error {
+	return it.valueIt.Close()
+}
+
+// encodeCursor returns an opaque cursor resuming after value.
+func encodeCursor(value quad.Value) (string, error) {
+	data, err := json.Marshal(jsonld.FromValue(value))
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// decodeCursor returns the value encoded in cursor or nil for an empty cursor.
+func decodeCursor(cursor string) (quad.Value, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor: %v", err)
+	}
+	var a interface{}
+	if err := json.Unmarshal(data, &a); err != nil {
+		return nil, fmt.Errorf("invalid cursor: %v", err)
+	}
+	value, err := parseValue(a)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor: %v", err)
+	}
+	if s, ok := value.(quad.TypedString); ok {
+		// restore numbers and dates so they are compared by value
+		if v, err := s.ParseValue(); err == nil {
+			value = v
+		}
+	}
+	return value, nil
+}
@@ -0,0 +1,49 @@
+package linkedql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cayleygraph/cayley/graph/memstore"
+	"github.com/stretchr/testify/require"
+)
+
+func paginate(t *testing.T, step *Paginate) document {
+	store := memstore.New(singleQuadData...)
+	it, err := step.BuildIterator(store)
+	require.NoError(t, err)
+	ctx := context.TODO()
+	require.True(t, it.Next(ctx))
+	d := it.Result().(document)
+	require.False(t, it.Next(ctx))
+	require.NoError(t, it.Err())
+	return d
+}
+
+func TestPaginate(t *testing.T) {
+	first := paginate(t, &Paginate{From: &Vertex{}, PageSize: 2})
+	require.Equal(t, []interface{}{
+		map[string]string{"@id": "alice"},
+		map[string]string{"@id": "bob"},
+	}, first["results"])
+	cursor, ok := first["nextCursor"].(string)
+	require.True(t, ok)
+
+	second := paginate(t, &Paginate{From: &Vertex{}, PageSize: 2, Cursor: cursor})
+	require.Equal(t, document{
+		"results": []interface{}{
+			map[string]string{"@id": "likes"},
+		},
+	}, second)
+
+	// resuming twice from the same cursor returns the same page
+	require.Equal(t, second, paginate(t, &Paginate{From: &Vertex{}, PageSize: 2, Cursor: cursor}))
+}
+
+func TestPaginateErrors(t *testing.T) {
+	store := memstore.New(singleQuadData...)
+	_, err := (&Paginate{From: &Vertex{}}).BuildIterator(store)
+	require.Error(t, err)
+	_, err = (&Paginate{From: &Vertex{}, PageSize: 2, Cursor: "!"}).BuildIterator(store)
+	require.Error(t, err)
+}
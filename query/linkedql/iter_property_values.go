@@ -0,0 +1,111 @@
+package linkedql
+
+import (
+	"context"
+
+	"github.com/cayleygraph/cayley/graph"
+	"github.com/cayleygraph/cayley/query"
+	"github.com/cayleygraph/quad"
+	"github.com/cayleygraph/quad/jsonld"
+)
+
+var _ query.Iterator = (*PropertyValuesIterator)(nil)
+
+// PropertyValuesIterator is an iterator of documents of the distinct values of a ValueIterator
+// with all their properties.
+type PropertyValuesIterator struct {
+	qs      graph.QuadStore
+	valueIt *ValueIterator
+	seen    map[string]struct{}
+	result  document
+	err     error
+}
+
+// NewPropertyValuesIterator returns a new PropertyValuesIterator for the values of valueIt.
+func NewPropertyValuesIterator(qs graph.QuadStore, valueIt *ValueIterator) *PropertyValuesIterator {
+	return &PropertyValuesIterator{qs: qs, valueIt: valueIt, seen: make(map[string]struct{})}
+}
+
+// Next implements query.Iterator.
+func (it *PropertyValuesIterator) Next(ctx context.Context) bool {
+	it.result = nil
+	if it.err != nil {
+		return false
+	}
+	for it.valueIt.Next(ctx) {
+		value := it.valueIt.Value()
+		if value == nil {
+			continue
+		}
+		if _, ok := it.seen[value.String()]; ok {
+			continue
+		}
+		it.seen[value.String()] = struct{}{}
+		it.result, it.err = nodeDocument(ctx, it.qs, value)
+		return it.err == nil
+	}
+	return false
+}
+
+// nodeDocument returns the document of node with all its properties.
+func nodeDocument(ctx context.Context, qs graph.QuadStore, node quad.Value) (document, error) {
+	ref := qs.ValueOf(node)
+	if ref == nil {
+		return newDocument(node, nil), nil
+	}
+	props, err := nodeProperties(ctx, qs, ref, quad.Subject)
+	if err != nil {
+		return nil, err
+	}
+	return newDocument(node, props), nil
+}
+
+// nodeProperties returns the properties of the quads having the node of ref in the direction dir,
+// subject or object, with the values on the other end of the quads.
+func nodeProperties(ctx context.Context, qs graph.QuadStore, ref graph.Ref, dir quad.Direction) (properties, error) {
+	other := quad.Object
+	if dir == quad.Object {
+		other = quad.Subject
+	}
+	sc := qs.QuadIterator(dir, ref).Iterate()
+	defer sc.Close()
+	props := make(properties)
+	for sc.Next(ctx) {
+		q := qs.Quad(sc.Result())
+		key := propertyKey(q.Predicate)
+		props[key] = append(props[key], jsonld.FromValue(q.Get(other)))
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return props, nil
+}
+
+// propertyKey returns the key of the values of a predicate in a document.
+func propertyKey(predicate quad.Value) string {
+	if iri, ok := predicate.(quad.IRI); ok {
+		return string(iri)
+	}
+	return quad.StringOf(predicate)
+}
+
+// Result implements query.Iterator.
+func (it *PropertyValuesIterator) Result() interface{} {
+	if it.result == nil {
+		return nil
+	}
+	return it.result
+}
+
+// Err implements query.Iterator.
+func (it *PropertyValuesIterator) Err() error {
+	if it.err != nil {
+		return it.err
+	}
+	return it.valueIt.Err()
+}
+
+// Close implements query.Iterator.
+func (it *PropertyValuesIterator) Close() error {
+	return it.valueIt.Close()
+}
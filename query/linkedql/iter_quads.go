@@ -0,0 +1,76 @@
+package linkedql
+
+import (
+	"context"
+
+	"github.com/cayleygraph/cayley/graph"
+	"github.com/cayleygraph/cayley/graph/iterator"
+	"github.com/cayleygraph/cayley/query"
+	"github.com/cayleygraph/quad"
+	"github.com/cayleygraph/quad/jsonld"
+)
+
+var _ query.Iterator = (*QuadsIterator)(nil)
+
+// QuadsIterator is an iterator of documents of the quads of a quads iterator.
+type QuadsIterator struct {
+	qs      graph.QuadStore
+	it      iterator.Shape
+	scanner iterator.Scanner
+	result  document
+}
+
+// NewQuadsIterator returns a new QuadsIterator for the quads of it.
+func NewQuadsIterator(qs graph.QuadStore, it iterator.Shape) *QuadsIterator {
+	return &QuadsIterator{qs: qs, it: it}
+}
+
+// Next implements query.Iterator.
+func (it *QuadsIterator) Next(ctx context.Context) bool {
+	it.result = nil
+	if it.scanner == nil {
+		it.scanner = it.it.Iterate()
+	}
+	if !it.scanner.Next(ctx) {
+		return false
+	}
+	it.result = quadDocument(it.qs.Quad(it.scanner.Result()))
+	return true
+}
+
+// quadDocument returns the document of q with a slot for each direction.
+func quadDocument(q quad.Quad) document {
+	d := make(document, 4)
+	for _, dir := range []quad.Direction{quad.Subject, quad.Predicate, quad.Object, quad.Label} {
+		var v interface{}
+		if value := q.Get(dir); value != nil {
+			v = jsonld.FromValue(value)
+		}
+		d[dir.String()] = v
+	}
+	return d
+}
+
+// Result implements query.Iterator.
+func (it *QuadsIterator) Result() interface{} {
+	if it.result == nil {
+		return nil
+	}
+	return it.result
+}
+
+// Err implements query.Iterator.
+func (it *QuadsIterator) Err() error {
+	if it.scanner == nil {
+		return nil
+	}
+	return it.scanner.Err()
+}
+
+// Close implements query.Iterator.
+func (it *QuadsIterator) Close() error {
+	if it.scanner == nil {
+		return nil
+	}
+	return it.scanner.Close()
+}
@@ -0,0 +1,74 @@
+package linkedql
+
+import (
+	"context"
+	"math/rand"
+
+	"github.com/cayleygraph/cayley/query"
+	"github.com/cayleygraph/quad"
+	"github.com/cayleygraph/quad/jsonld"
+)
+
+var _ query.Iterator = (*SampleIterator)(nil)
+
+// SampleIterator is an iterator of a random sample of the values of a ValueIterator.
+// It reads the values once, using reservoir sampling, and only holds the sampled values.
+type SampleIterator struct {
+	valueIt *ValueIterator
+	rand    *rand.Rand
+	sample  []quad.Value
+	sampled bool
+	current int
+}
+
+// NewSampleIterator returns a new SampleIterator of count values of valueIt, chosen using the given seed.
+func NewSampleIterator(valueIt *ValueIterator, count int, seed int64) *SampleIterator {
+	return &SampleIterator{
+		valueIt: valueIt,
+		rand:    rand.New(rand.NewSource(seed)),
+		sample:  make([]quad.Value, 0, count),
+		current: -1,
+	}
+}
+
+// Next implements query.Iterator.
+func (it *SampleIterator) Next(ctx context.Context) bool {
+	if !it.sampled {
+		it.sampled = true
+		size := cap(it.sample)
+		for n := 0; it.valueIt.Next(ctx); n++ {
+			value := it.valueIt.Value()
+			if len(it.sample) < size {
+				it.sample = append(it.sample, value)
+				continue
+			}
+			// replace a sampled value with probability size/(n+1)
+			if i := it.rand.Intn(n + 1); i < size {
+				it.sample[i] = value
+			}
+		}
+	}
+	if it.current < len(it.sample)-1 {
+		it.current++
+		return true
+	}
+	return false
+}
+
+// Result implements query.Iterator.
+func (it *SampleIterator) Result() interface{} {
+	if it.current < 0 || it.current >= len(it.sample) {
+		return nil
+	}
+	return jsonld.FromValue(it.sample[it.current])
+}
+
+// Err implements query.Iterator.
+func (it *SampleIterator) Err() error {
+	return it.valueIt.Err()
+}
+
+// Close implements query.Iterator.
+func (it *SampleIterator) Close() error {
+	return it.valueIt.Close()
+}
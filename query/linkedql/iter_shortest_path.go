@@ -0,0 +1,157 @@
+package linkedql
+
+import (
+	"context"
+	"sort"
+
+	"github.com/cayleygraph/cayley/graph"
+	"github.com/cayleygraph/cayley/query"
+	"github.com/cayleygraph/cayley/query/path"
+	"github.com/cayleygraph/quad"
+	"github.com/cayleygraph/quad/jsonld"
+)
+
+var _ query.Iterator = (*ShortestPathIterator)(nil)
+
+// shortestPathParentTag is the tag ShortestPathIterator uses internally to collect the node an edge starts from.
+const shortestPathParentTag = Prefix + "parent"
+
+// ShortestPathIterator is an iterator emitting the shortest path from the values of a ValueIterator
+// to one of the targets, following the properties of via.
+type ShortestPathIterator struct {
+	qs      graph.QuadStore
+	fromIt  *ValueIterator
+	targets map[string]struct{}
+	via     *path.Path
+	nodes   []quad.Value
+	done    bool
+	err     error
+}
+
+// NewShortestPathIterator returns a new ShortestPathIterator.
+func NewShortestPathIterator(qs graph.QuadStore, fromIt *ValueIterator, targets []quad.Value, via *path.Path) *ShortestPathIterator {
+	set := make(map[string]struct{}, len(targets))
+	for _, target := range targets {
+		set[target.String()] = struct{}{}
+	}
+	return &ShortestPathIterator{qs: qs, fromIt: fromIt, targets: set, via: via}
+}
+
+// Next implements query.Iterator.
+func (it *ShortestPathIterator) Next(ctx context.Context) bool {
+	if it.done {
+		return false
+	}
+	it.done = true
+	// parents maps every visited node to the node it was reached from, nil for the start nodes
+	parents := make(map[string]quad.Value)
+	var frontier []quad.Value
+	for it.fromIt.Next(ctx) {
+		value := it.fromIt.Value()
+		if value == nil {
+			continue
+		}
+		if _, ok := parents[value.String()]; ok {
+			continue
+		}
+		parents[value.String()] = nil
+		frontier = append(frontier, value)
+	}
+	if it.fromIt.Err() != nil {
+		return false
+	}
+	for len(frontier) > 0 {
+		sort.Slice(frontier, func(i, j int) bool {
+			return lessValue(frontier[i], frontier[j])
+		})
+		for _, node := range frontier {
+			if _, ok := it.targets[node.String()]; ok {
+				it.nodes = pathTo(node, parents)
+				return true
+			}
+		}
+		next, err := it.expand(ctx, frontier, parents)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		frontier = next
+	}
+	return false
+}
+
+// expand visits the nodes adjacent to the frontier and returns the ones that were not visited before.
+func (it *ShortestPathIterator) expand(ctx context.Context, frontier []quad.Value, parents map[string]quad.Value) ([]quad.Value, error) {
+	p := path.StartPath(it.qs, frontier...).Tag(shortestPathParentTag).Out(it.via)
+	tagsIt := &TagsIterator{valueIt: NewValueIterator(p, it.qs), selected: []string{shortestPathParentTag}}
+	defer tagsIt.Close()
+	level := make(map[string]quad.Value)
+	var next []quad.Value
+	for tagsIt.Next(ctx) {
+		node := tagsIt.valueIt.Value()
+		parent := tagsIt.getTagValues()[shortestPathParentTag]
+		if node == nil || parent == nil {
+			continue
+		}
+		key := node.String()
+		if _, ok := parents[key]; ok {
+			continue
+		}
+		if former, ok := level[key]; ok {
+			// prefer the smallest parent so the result is deterministic
+			if lessValue(parent, former) {
+				level[key] = parent
+			}
+			continue
+		}
+		level[key] = parent
+		next = append(next, node)
+	}
+	if err := tagsIt.Err(); err != nil {
+		return nil, err
+	}
+	for key, parent := range level {
+		parents[key] = parent
+	}
+	return next, nil
+}
+
+// pathTo returns the nodes from a start node to node.
+func pathTo(node quad.Value, parents map[string]quad.Value) []quad.Value {
+	var nodes []quad.Value
+	for node != nil {
+		nodes = append(nodes, node)
+		node = parents[node.String()]
+	}
+	for i, j := 0, len(nodes)-1; i < j; i, j = i+1, j-1 {
+		nodes[i], nodes[j] = nodes[j], nodes[i]
+	}
+	return nodes
+}
+
+// Result implements query.Iterator.
+func (it *ShortestPathIterator) Result() interface{} {
+	if it.nodes == nil {
+		return nil
+	}
+	list := make([]interface{}, 0, len(it.nodes))
+	for _, node := range it.nodes {
+		list = append(list, jsonld.FromValue(node))
+	}
+	return document{
+		"@list": list,
+	}
+}
+
+// Err implements query.Iterator.
+func (it *ShortestPathIterator) Err() error {
+	if it.err != nil {
+		return it.err
+	}
+	return it.fromIt.Err()
+}
+
+// Close implements query.Iterator.
+func (it *ShortestPathIterator) Close() error {
+	return it.fromIt.Close()
+}
@@ -0,0 +1,70 @@
+package linkedql
+
+import (
+	"context"
+	"strings"
+
+	"github.com/cayleygraph/cayley/query"
+	"github.com/cayleygraph/quad"
+	"github.com/cayleygraph/quad/jsonld"
+)
+
+var _ query.Iterator = (*StringJoinIterator)(nil)
+
+// StringJoinIterator is an iterator resolving to the lexical forms of the values
+// of an underlying ValueIterator joined with a separator.
+type StringJoinIterator struct {
+	valueIt   *ValueIterator
+	separator string
+	result    quad.Value
+	done      bool
+}
+
+// NewStringJoinIterator returns a new StringJoinIterator for a ValueIterator.
+func NewStringJoinIterator(valueIt *ValueIterator, separator string) *StringJoinIterator {
+	return &StringJoinIterator{valueIt: valueIt, separator: separator}
+}
+
+// Next implements query.Iterator.
+func (it *StringJoinIterator) Next(ctx context.Context) bool {
+	if it.done {
+		return false
+	}
+	it.done = true
+	var values []string
+	for it.valueIt.Next(ctx) {
+		v := it.valueIt.Value()
+		if v == nil {
+			continue
+		}
+		values = append(values, lexicalForm(v))
+	}
+	if len(values) == 0 || it.valueIt.Err() != nil {
+		return false
+	}
+	it.result = quad.String(strings.Join(values, it.separator))
+	return true
+}
+
+// Value returns the current value
+func (it *StringJoinIterator) Value() quad.Value {
+	return it.result
+}
+
+// Result implements query.Iterator.
+func (it *StringJoinIterator) Result() interface{} {
+	if it.result == nil {
+		return nil
+	}
+	return jsonld.FromValue(it.result)
+}
+
+// Err implements query.Iterator.
+func (it *StringJoinIterator) Err() error {
+	return it.valueIt.Err()
+}
+
+// Close implements query.Iterator.
+func (it *StringJoinIterator) Close() error {
+	return it.valueIt.Close()
+}
@@ -2,9 +2,11 @@ package linkedql
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/cayleygraph/cayley/graph/refs"
 	"github.com/cayleygraph/cayley/query"
+	"github.com/cayleygraph/quad"
 	"github.com/cayleygraph/quad/jsonld"
 )
 
@@ -15,42 +17,123 @@ var _ query.Iterator = (*TagsIterator)(nil)
 type TagsIterator struct {
 	valueIt  *ValueIterator
 	selected []string
+	// SkipErrors makes the iterator skip results with tags that can not be
+	// materialized instead of failing. See SkippedCount.
+	SkipErrors bool
+	// FillMissing makes the iterator set selected tags without a value to
+	// null instead of failing.
+	FillMissing bool
+	skipped     int
+	tags        map[string]interface{}
+	err         error
 }
 
 // Next implements query.Iterator.
 func (it *TagsIterator) Next(ctx context.Context) bool {
-	return it.valueIt.Next(ctx)
+	it.tags = nil
+	if it.err != nil {
+		return false
+	}
+	for it.valueIt.Next(ctx) {
+		if !it.SkipErrors {
+			return true
+		}
+		tags, err := it.materialize()
+		if err != nil {
+			it.skipped++
+			continue
+		}
+		it.tags = tags
+		return true
+	}
+	return false
 }
 
-func (it *TagsIterator) getTags() map[string]interface{} {
+// SkippedCount returns the number of results skipped so far because of SkipErrors.
+func (it *TagsIterator) SkippedCount() int {
+	return it.skipped
+}
+
+func (it *TagsIterator) getTagValues() map[string]quad.Value {
 	refTags := make(map[string]refs.Ref)
 	it.valueIt.scanner.TagResults(refTags)
 
-	tags := make(map[string]interface{})
-	// FIXME(iddan): only convert when collation is JSON/JSON-LD, leave as Ref otherwise
+	values := make(map[string]quad.Value)
 	if it.selected != nil {
 		for _, tag := range it.selected {
-			tags[tag] = jsonld.FromValue(it.valueIt.getName(refTags[tag]))
+			values[tag] = it.valueIt.getName(refTags[tag])
 		}
 	} else {
 		for tag, ref := range refTags {
-			tags[tag] = jsonld.FromValue(it.valueIt.getName(ref))
+			values[tag] = it.valueIt.getName(ref)
 		}
 	}
 
+	return values
+}
+
+func (it *TagsIterator) getTags() map[string]interface{} {
+	tags := make(map[string]interface{})
+	// FIXME(iddan): only convert when collation is JSON/JSON-LD, leave as Ref otherwise
+	for tag, value := range it.getTagValues() {
+		tags[tag] = jsonld.FromValue(value)
+	}
 	return tags
 }
 
+// materialize returns the tags of the current result as JSON-LD or an error if
+// any of them has no value or can not be converted.
+func (it *TagsIterator) materialize() (tags map[string]interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			tags, err = nil, fmt.Errorf("can not materialize tags: %v", r)
+		}
+	}()
+	tags = make(map[string]interface{})
+	for tag, value := range it.getTagValues() {
+		if value == nil {
+			if it.FillMissing {
+				tags[tag] = nil
+				continue
+			}
+			return nil, fmt.Errorf("no value for tag %q", tag)
+		}
+		tags[tag] = jsonld.FromValue(value)
+	}
+	return tags, nil
+}
+
 // Result implements query.Iterator.
 func (it *TagsIterator) Result() interface{} {
-	return it.getTags()
+	if it.tags != nil {
+		return it.tags
+	}
+	tags, err := it.materialize()
+	if err != nil {
+		it.err = err
+		return nil
+	}
+	it.tags = tags
+	return tags
 }
 
 // Err implements query.Iterator.
 func (it *TagsIterator) Err() error {
+	if it.err != nil {
+		return it.err
+	}
 	return it.valueIt.Err()
 }
 
+// Reset rewinds the iterator so the next call to Next restarts from the first result.
+// See ValueIterator.Reset for its cost.
+func (it *TagsIterator) Reset() error {
+	it.tags = nil
+	it.err = nil
+	it.skipped = 0
+	return it.valueIt.Reset()
+}
+
 // Close implements query.Iterator.
 func (it *TagsIterator) Close() error {
 	return it.valueIt.Close()
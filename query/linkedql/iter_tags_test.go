@@ -0,0 +1,75 @@
+package linkedql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cayleygraph/cayley/graph/memstore"
+	"github.com/cayleygraph/cayley/query/path"
+	"github.com/cayleygraph/quad"
+	"github.com/stretchr/testify/require"
+)
+
+func newOptionalTagsIterator(skipErrors bool) *TagsIterator {
+	store := memstore.New(
+		quad.MakeIRI("alice", "likes", "bob", ""),
+		quad.MakeIRI("bob", "name", "Bob", ""),
+		quad.MakeIRI("carol", "likes", "dan", ""),
+	)
+	// bob has no likes, so the liked tag fails to materialize for him
+	p := path.StartPath(store, quad.IRI("alice"), quad.IRI("bob"), quad.IRI("carol")).
+		SaveOptional(quad.IRI("likes"), "liked")
+	return &TagsIterator{
+		valueIt:    NewValueIterator(p, store),
+		selected:   []string{"liked"},
+		SkipErrors: skipErrors,
+	}
+}
+
+func TestTagsIteratorSkipErrors(t *testing.T) {
+	ctx := context.TODO()
+	it := newOptionalTagsIterator(true)
+	var results []interface{}
+	for it.Next(ctx) {
+		results = append(results, it.Result())
+	}
+	require.NoError(t, it.Err())
+	require.Equal(t, []interface{}{
+		map[string]interface{}{"liked": map[string]string{"@id": "bob"}},
+		map[string]interface{}{"liked": map[string]string{"@id": "dan"}},
+	}, results)
+	require.Equal(t, 1, it.SkippedCount())
+}
+
+func TestTagsIteratorFailsOnError(t *testing.T) {
+	ctx := context.TODO()
+	it := newOptionalTagsIterator(false)
+	var results []interface{}
+	for it.Next(ctx) {
+		if r := it.Result(); r != nil {
+			results = append(results, r)
+		}
+	}
+	require.Error(t, it.Err())
+	require.Len(t, results, 1)
+	require.Equal(t, 0, it.SkippedCount())
+}
+
+func TestTagsIteratorReset(t *testing.T) {
+	ctx := context.TODO()
+	it := newOptionalTagsIterator(true)
+	collect := func() []interface{} {
+		var results []interface{}
+		for it.Next(ctx) {
+			results = append(results, it.Result())
+		}
+		require.NoError(t, it.Err())
+		return results
+	}
+	first := collect()
+	require.Len(t, first, 2)
+	require.NoError(t, it.Reset())
+	require.Equal(t, first, collect())
+	require.Equal(t, 1, it.SkippedCount())
+	require.NoError(t, it.Close())
+}
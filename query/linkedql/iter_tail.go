@@ -0,0 +1,70 @@
+package linkedql
+
+import (
+	"context"
+
+	"github.com/cayleygraph/cayley/query"
+	"github.com/cayleygraph/quad"
+	"github.com/cayleygraph/quad/jsonld"
+)
+
+var _ query.Iterator = (*TailIterator)(nil)
+
+// TailIterator is an iterator of the last values of a ValueIterator.
+// It only holds as many values as it returns.
+type TailIterator struct {
+	valueIt *ValueIterator
+	ring    []quad.Value
+	// start is the position of the oldest value in ring once it is full
+	start   int
+	filled  bool
+	current int
+}
+
+// NewTailIterator returns a new TailIterator of the last count values of valueIt.
+func NewTailIterator(valueIt *ValueIterator, count int) *TailIterator {
+	return &TailIterator{valueIt: valueIt, ring: make([]quad.Value, 0, count), current: -1}
+}
+
+// Next implements query.Iterator.
+func (it *TailIterator) Next(ctx context.Context) bool {
+	if !it.filled {
+		it.filled = true
+		size := cap(it.ring)
+		for it.valueIt.Next(ctx) {
+			if size == 0 {
+				continue
+			}
+			value := it.valueIt.Value()
+			if len(it.ring) < size {
+				it.ring = append(it.ring, value)
+				continue
+			}
+			it.ring[it.start] = value
+			it.start = (it.start + 1) % size
+		}
+	}
+	if it.current < len(it.ring)-1 {
+		it.current++
+		return true
+	}
+	return false
+}
+
+// Result implements query.Iterator.
+func (it *TailIterator) Result() interface{} {
+	if it.current < 0 || it.current >= len(it.ring) {
+		return nil
+	}
+	return jsonld.FromValue(it.ring[(it.start+it.current)%len(it.ring)])
+}
+
+// Err implements query.Iterator.
+func (it *TailIterator) Err() error {
+	return it.valueIt.Err()
+}
+
+// Close implements query.Iterator.
+func (it *TailIterator) Close() error {
+	return it.valueIt.Close()
+}
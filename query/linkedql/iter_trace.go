@@ -0,0 +1,61 @@
+package linkedql
+
+import (
+	"context"
+
+	"github.com/cayleygraph/cayley/query"
+	"github.com/cayleygraph/quad/jsonld"
+)
+
+var _ query.Iterator = (*TraceIterator)(nil)
+
+// TraceIterator is an iterator of documents of the values of a TagsIterator
+// holding under "@path" the values of the given tags, in order, followed by the value.
+type TraceIterator struct {
+	tagsIt *TagsIterator
+	tags   []string
+}
+
+// NewTraceIterator returns a new TraceIterator tracing the values of tagsIt through tags.
+func NewTraceIterator(tagsIt *TagsIterator, tags []string) *TraceIterator {
+	return &TraceIterator{tagsIt: tagsIt, tags: tags}
+}
+
+// Next implements query.Iterator.
+func (it *TraceIterator) Next(ctx context.Context) bool {
+	return it.tagsIt.Next(ctx)
+}
+
+// Result implements query.Iterator.
+func (it *TraceIterator) Result() interface{} {
+	values := it.tagsIt.getTagValues()
+	var trace []interface{}
+	for _, tag := range it.tags {
+		if v := values[tag]; v != nil {
+			trace = append(trace, jsonld.FromValue(v))
+		}
+	}
+	value := it.tagsIt.valueIt.Value()
+	trace = append(trace, jsonld.FromValue(value))
+	doc := make(document)
+	switch r := jsonld.FromValue(value).(type) {
+	case map[string]string:
+		for k, v := range r {
+			doc[k] = v
+		}
+	default:
+		doc["@value"] = r
+	}
+	doc["@path"] = trace
+	return doc
+}
+
+// Err implements query.Iterator.
+func (it *TraceIterator) Err() error {
+	return it.tagsIt.Err()
+}
+
+// Close implements query.Iterator.
+func (it *TraceIterator) Close() error {
+	return it.tagsIt.Close()
+}
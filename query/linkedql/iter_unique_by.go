@@ -0,0 +1,59 @@
+package linkedql
+
+import (
+	"context"
+
+	"github.com/cayleygraph/cayley/query"
+	"github.com/cayleygraph/quad"
+)
+
+var _ query.Iterator = (*UniqueByIterator)(nil)
+
+// UniqueByIterator is an iterator of values of a TagsIterator skipping values sharing the value of a tag with a former value.
+// Values missing the tag are never skipped.
+type UniqueByIterator struct {
+	tagsIt *TagsIterator
+	tag    string
+	seen   map[string]struct{}
+}
+
+// NewUniqueByIterator returns a new UniqueByIterator deduplicating the values of tagsIt by tag.
+func NewUniqueByIterator(tagsIt *TagsIterator, tag string) *UniqueByIterator {
+	return &UniqueByIterator{tagsIt: tagsIt, tag: tag, seen: make(map[string]struct{})}
+}
+
+// Next implements query.Iterator.
+func (it *UniqueByIterator) Next(ctx context.Context) bool {
+	for it.tagsIt.Next(ctx) {
+		key := it.tagsIt.getTagValues()[it.tag]
+		if key == nil {
+			return true
+		}
+		if _, ok := it.seen[key.String()]; ok {
+			continue
+		}
+		it.seen[key.String()] = struct{}{}
+		return true
+	}
+	return false
+}
+
+// Value returns the current value
+func (it *UniqueByIterator) Value() quad.Value {
+	return it.tagsIt.valueIt.Value()
+}
+
+// Result implements query.Iterator.
+func (it *UniqueByIterator) Result() interface{} {
+	return it.tagsIt.valueIt.Result()
+}
+
+// Err implements query.Iterator.
+func (it *UniqueByIterator) Err() error {
+	return it.tagsIt.Err()
+}
+
+// Close implements query.Iterator.
+func (it *UniqueByIterator) Close() error {
+	return it.tagsIt.Close()
+}
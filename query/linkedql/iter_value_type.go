@@ -0,0 +1,69 @@
+package linkedql
+
+import (
+	"context"
+
+	"github.com/cayleygraph/cayley/query"
+	"github.com/cayleygraph/quad"
+)
+
+var _ query.Iterator = (*ValueTypeIterator)(nil)
+
+// ValueTypeIterator is an iterator of the kinds of the values of a ValueIterator.
+type ValueTypeIterator struct {
+	valueIt *ValueIterator
+}
+
+// NewValueTypeIterator returns a new ValueTypeIterator for the values of valueIt.
+func NewValueTypeIterator(valueIt *ValueIterator) *ValueTypeIterator {
+	return &ValueTypeIterator{valueIt: valueIt}
+}
+
+// Next implements query.Iterator.
+func (it *ValueTypeIterator) Next(ctx context.Context) bool {
+	return it.valueIt.Next(ctx)
+}
+
+// Result implements query.Iterator.
+func (it *ValueTypeIterator) Result() interface{} {
+	value := it.valueIt.Value()
+	if value == nil {
+		return nil
+	}
+	return valueType(value)
+}
+
+// valueType returns the name of the kind of v.
+func valueType(v quad.Value) string {
+	switch v.(type) {
+	case quad.IRI:
+		return "iri"
+	case quad.BNode:
+		return "bnode"
+	case quad.String:
+		return "string"
+	case quad.LangString:
+		return "langString"
+	case quad.TypedString:
+		return "typedString"
+	case quad.Int:
+		return "int"
+	case quad.Float:
+		return "float"
+	case quad.Bool:
+		return "bool"
+	case quad.Time:
+		return "time"
+	}
+	return "unknown"
+}
+
+// Err implements query.Iterator.
+func (it *ValueTypeIterator) Err() error {
+	return it.valueIt.Err()
+}
+
+// Close implements query.Iterator.
+func (it *ValueTypeIterator) Close() error {
+	return it.valueIt.Close()
+}
@@ -19,6 +19,16 @@ type ValueIterator struct {
 	namer   refs.Namer
 	path    *path.Path
 	scanner iterator.Scanner
+	err     error
+	stats   ValueIteratorStats
+}
+
+// ValueIteratorStats are the counters of a ValueIterator.
+type ValueIteratorStats struct {
+	// Results is the number of values emitted.
+	Results int64
+	// NextCalls is the number of calls to Next.
+	NextCalls int64
 }
 
 // NewValueIterator returns a new ValueIterator for a path and namer.
@@ -37,11 +47,29 @@ func NewValueIteratorFromPathStep(step PathStep, qs graph.QuadStore) (*ValueIter
 }
 
 // Next implements query.Iterator.
+// It stops if ctx is done, in which case Err returns the error of ctx.
 func (it *ValueIterator) Next(ctx context.Context) bool {
+	it.stats.NextCalls++
+	if it.err != nil {
+		return false
+	}
+	if err := ctx.Err(); err != nil {
+		it.err = err
+		return false
+	}
 	if it.scanner == nil {
 		it.scanner = it.path.BuildIterator(ctx).Iterate()
 	}
-	return it.scanner.Next(ctx)
+	if !it.scanner.Next(ctx) {
+		return false
+	}
+	it.stats.Results++
+	return true
+}
+
+// Stats returns the counters of the iterator so far.
+func (it *ValueIterator) Stats() ValueIteratorStats {
+	return it.stats
 }
 
 func (it *ValueIterator) getName(ref refs.Ref) quad.Value {
@@ -65,12 +93,28 @@ func (it *ValueIterator) Result() interface{} {
 
 // Err implements query.Iterator.
 func (it *ValueIterator) Err() error {
+	if it.err != nil {
+		return it.err
+	}
 	if it.scanner == nil {
 		return nil
 	}
 	return it.scanner.Err()
 }
 
+// Reset rewinds the iterator so the next call to Next restarts from the first value.
+// The iterator of the path is closed and built again, so iterating again costs as much
+// as running the query a second time. Stats are not reset.
+func (it *ValueIterator) Reset() error {
+	it.err = nil
+	if it.scanner == nil {
+		return nil
+	}
+	err := it.scanner.Close()
+	it.scanner = nil
+	return err
+}
+
 // Close implements query.Iterator.
 func (it *ValueIterator) Close() error {
 	if it.scanner == nil {
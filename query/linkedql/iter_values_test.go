@@ -0,0 +1,55 @@
+package linkedql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cayleygraph/cayley/graph/memstore"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValueIteratorCancel(t *testing.T) {
+	store := memstore.New(singleQuadData...)
+	it, err := NewValueIteratorFromPathStep(&Vertex{}, store)
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	require.True(t, it.Next(ctx))
+	cancel()
+	require.False(t, it.Next(ctx))
+	require.Equal(t, context.Canceled, it.Err())
+	require.NoError(t, it.Close())
+}
+
+func TestValueIteratorStats(t *testing.T) {
+	store := memstore.New(singleQuadData...)
+	it, err := NewValueIteratorFromPathStep(&Vertex{}, store)
+	require.NoError(t, err)
+	ctx := context.TODO()
+	require.Equal(t, ValueIteratorStats{}, it.Stats())
+	for it.Next(ctx) {
+	}
+	require.NoError(t, it.Err())
+	// alice, likes and bob followed by the last call returning false
+	require.Equal(t, ValueIteratorStats{Results: 3, NextCalls: 4}, it.Stats())
+}
+
+func TestValueIteratorReset(t *testing.T) {
+	store := memstore.New(singleQuadData...)
+	it, err := NewValueIteratorFromPathStep(&Vertex{}, store)
+	require.NoError(t, err)
+	ctx := context.TODO()
+	collect := func() []interface{} {
+		var results []interface{}
+		for it.Next(ctx) {
+			results = append(results, it.Result())
+		}
+		require.NoError(t, it.Err())
+		return results
+	}
+	first := collect()
+	require.Len(t, first, 3)
+	require.NoError(t, it.Reset())
+	require.Equal(t, first, collect())
+	require.NoError(t, it.Close())
+}
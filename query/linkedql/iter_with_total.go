@@ -0,0 +1,109 @@
+package linkedql
+
+import (
+	"context"
+
+	"github.com/cayleygraph/cayley/query"
+	"github.com/cayleygraph/cayley/query/path"
+	"github.com/cayleygraph/quad"
+	"github.com/cayleygraph/quad/jsonld"
+)
+
+var _ query.Iterator = (*WithTotalIterator)(nil)
+
+// WithTotalIterator is an iterator emitting a single document with the values of a ValueIterator,
+// or the page of a PaginateIterator, as items and the number of results of the unpaginated path as total.
+type WithTotalIterator struct {
+	it      query.Iterator
+	valueIt *ValueIterator
+	pageIt  *PaginateIterator
+	total   *path.Path
+	items   []quad.Value
+	next    string
+	count   int64
+	done    bool
+	err     error
+}
+
+// NewWithTotalIterator returns a new WithTotalIterator for a ValueIterator and the path to count the total of.
+func NewWithTotalIterator(valueIt *ValueIterator, total *path.Path) *WithTotalIterator {
+	return &WithTotalIterator{it: valueIt, valueIt: valueIt, total: total}
+}
+
+// NewPageWithTotalIterator returns a new WithTotalIterator for the page of a PaginateIterator and the path to count the total of.
+// The cursor of the next page, if any, is emitted as nextCursor.
+func NewPageWithTotalIterator(pageIt *PaginateIterator, total *path.Path) *WithTotalIterator {
+	return &WithTotalIterator{it: pageIt, pageIt: pageIt, total: total}
+}
+
+// Next implements query.Iterator.
+func (it *WithTotalIterator) Next(ctx context.Context) bool {
+	if it.done {
+		return false
+	}
+	it.done = true
+	if it.pageIt != nil {
+		if it.pageIt.Next(ctx) {
+			it.items, it.next = it.pageIt.page, it.pageIt.next
+		}
+	} else {
+		for it.valueIt.Next(ctx) {
+			if value := it.valueIt.Value(); value != nil {
+				it.items = append(it.items, value)
+			}
+		}
+	}
+	if it.it.Err() != nil {
+		return false
+	}
+	it.count, it.err = countPath(ctx, it.total)
+	return it.err == nil
+}
+
+// countPath returns the number of results of p.
+// The size reported by the store is used if it is exact, otherwise the results are counted.
+func countPath(ctx context.Context, p *path.Path) (int64, error) {
+	shape := p.BuildIterator(ctx)
+	if st, err := shape.Stats(ctx); err == nil && st.Size.Exact {
+		return st.Size.Value, nil
+	}
+	scanner := shape.Iterate()
+	defer scanner.Close()
+	var n int64
+	for scanner.Next(ctx) {
+		n++
+	}
+	return n, scanner.Err()
+}
+
+// Result implements query.Iterator.
+func (it *WithTotalIterator) Result() interface{} {
+	if !it.done || it.err != nil {
+		return nil
+	}
+	items := make([]interface{}, 0, len(it.items))
+	for _, value := range it.items {
+		items = append(items, jsonld.FromValue(value))
+	}
+	d := document{
+		"total": it.count,
+		"items": items,
+	}
+	if it.next != "" {
+		d["nextCursor"] = it.next
+	}
+	return d
+}
+
+// Err implements query.Iterator.
+func (it *WithTotalIterator) Err() error {
+	if it.err != nil {
+		return it.err
+	}
+	return it.it.Err()
+}
+
+// Close implements query.Iterator.
+func (it *WithTotalIterator) Close() error {
+	return it.it.Close()
+}
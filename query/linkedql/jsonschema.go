@@ -0,0 +1,191 @@
+package linkedql
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// jsonSchemaDraft is the version of JSON Schema produced by GenerateJSONSchema.
+const jsonSchemaDraft = "http://json-schema.org/draft-07/schema#"
+
+var iteratorStepType = reflect.TypeOf((*IteratorStep)(nil)).Elem()
+
+// GenerateJSONSchema returns a JSON Schema document describing all the registered types.
+// Every type is a definition named after its registration name, fields are named as in a query
+// and fields that must be set (see Validate) are listed as required.
+func GenerateJSONSchema() ([]byte, error) {
+	names := RegisteredTypes()
+	sort.Strings(names)
+	definitions := make(map[string]interface{}, len(names)+1)
+	for _, name := range names {
+		tp := typeByName[name]
+		def, err := typeJSONSchema(name, tp)
+		if err != nil {
+			return nil, err
+		}
+		definitions[name] = def
+	}
+	definitions[Prefix+"Value"] = map[string]interface{}{
+		"description": "a JSON-LD value: a string, a number, a boolean or an object with @id, @value, @type or @language",
+		"anyOf": []interface{}{
+			map[string]interface{}{"type": "string"},
+			map[string]interface{}{"type": "number"},
+			map[string]interface{}{"type": "boolean"},
+			map[string]interface{}{"type": "object"},
+		},
+	}
+	definitions[Prefix+"PropertyPath"] = propertyPathJSONSchema()
+	return json.Marshal(map[string]interface{}{
+		"$schema":     jsonSchemaDraft,
+		"definitions": definitions,
+	})
+}
+
+func typeJSONSchema(name string, tp reflect.Type) (map[string]interface{}, error) {
+	properties := map[string]interface{}{
+		"@type": map[string]interface{}{"const": name},
+	}
+	required := []string{"@type"}
+	if err := fieldsJSONSchema(tp, properties, &required); err != nil {
+		return nil, err
+	}
+	item := reflect.New(tp).Interface().(RegistryItem)
+	return map[string]interface{}{
+		"type":        "object",
+		"description": item.Description(),
+		"properties":  properties,
+		"required":    required,
+	}, nil
+}
+
+func fieldsJSONSchema(tp reflect.Type, properties map[string]interface{}, required *[]string) error {
+	for i := 0; i < tp.NumField(); i++ {
+		f := tp.Field(i)
+		if f.Anonymous && f.Type.Kind() == reflect.Struct {
+			if err := fieldsJSONSchema(f.Type, properties, required); err != nil {
+				return err
+			}
+			continue
+		}
+		name, ok := fieldName(f)
+		if !ok {
+			continue
+		}
+		name = Prefix + name
+		min, max, err := fieldCardinality(f)
+		if err != nil {
+			return err
+		}
+		schema, err := valueJSONSchema(f.Type)
+		if err != nil {
+			return fmt.Errorf("%s: field %s: %v", tp.Name(), f.Name, err)
+		}
+		if f.Type.Kind() == reflect.Slice {
+			if min > 0 {
+				schema["minItems"] = min
+			}
+			if max >= 0 {
+				schema["maxItems"] = max
+			}
+		}
+		properties[name] = schema
+		if min > 0 {
+			*required = append(*required, name)
+		}
+	}
+	return nil
+}
+
+func valueJSONSchema(t reflect.Type) (map[string]interface{}, error) {
+	switch t {
+	case quadValue:
+		return ref(Prefix + "Value"), nil
+	case quadIRI:
+		return map[string]interface{}{"type": "string"}, nil
+	case quadTime:
+		return map[string]interface{}{"type": "string", "format": "date-time"}, nil
+	case propertyPathType:
+		return ref(Prefix + "PropertyPath"), nil
+	case pathStepType, iteratorStepType, operatorType:
+		return implementationsJSONSchema(t), nil
+	case entityIdentifierType:
+		return map[string]interface{}{"type": "string"}, nil
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}, nil
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}, nil
+	case reflect.Int, reflect.Int64:
+		return map[string]interface{}{"type": "integer"}, nil
+	case reflect.Interface:
+		if t.NumMethod() == 0 {
+			// any JSON value
+			return map[string]interface{}{}, nil
+		}
+	case reflect.Map:
+		values, err := valueJSONSchema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"type": "object", "additionalProperties": values}, nil
+	case reflect.Slice:
+		items, err := valueJSONSchema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"type": "array", "items": items}, nil
+	}
+	return nil, fmt.Errorf("unsupported type %v", t)
+}
+
+// propertyPathJSONSchema returns a schema matching the forms of a PropertyPath.
+func propertyPathJSONSchema() map[string]interface{} {
+	iri := map[string]interface{}{
+		"anyOf": []interface{}{
+			map[string]interface{}{"type": "string"},
+			keyJSONSchema("@id", map[string]interface{}{"type": "string"}),
+		},
+	}
+	path := ref(Prefix + "PropertyPath")
+	return map[string]interface{}{
+		"description": "a property IRI, an array of property IRIs, a sequence of property paths with @list, a property path followed in reverse with @reverse, a property path followed zero or more times with linkedql:zeroOrMore or a path step",
+		"anyOf": []interface{}{
+			iri,
+			map[string]interface{}{"type": "array", "items": iri},
+			keyJSONSchema("@list", map[string]interface{}{"type": "array", "items": path}),
+			keyJSONSchema("@reverse", path),
+			keyJSONSchema(Prefix+"zeroOrMore", path),
+			implementationsJSONSchema(pathStepType),
+		},
+	}
+}
+
+// keyJSONSchema returns a schema matching an object with a single key of the given schema.
+func keyJSONSchema(key string, schema map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"type":                 "object",
+		"properties":           map[string]interface{}{key: schema},
+		"required":             []string{key},
+		"additionalProperties": false,
+	}
+}
+
+// implementationsJSONSchema returns a schema matching any of the registered types implementing iface.
+func implementationsJSONSchema(iface reflect.Type) map[string]interface{} {
+	names := RegisteredTypes()
+	sort.Strings(names)
+	var refs []interface{}
+	for _, name := range names {
+		if reflect.PtrTo(typeByName[name]).Implements(iface) {
+			refs = append(refs, ref(name))
+		}
+	}
+	return map[string]interface{}{"anyOf": refs}
+}
+
+func ref(name string) map[string]interface{} {
+	return map[string]interface{}{"$ref": "#/definitions/" + name}
+}
@@ -0,0 +1,114 @@
+package linkedql
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateJSONSchema(t *testing.T) {
+	data, err := GenerateJSONSchema()
+	require.NoError(t, err)
+	var schema struct {
+		Definitions map[string]struct {
+			Properties map[string]json.RawMessage `json:"properties"`
+			Required   []string                   `json:"required"`
+		} `json:"definitions"`
+	}
+	require.NoError(t, json.Unmarshal(data, &schema))
+	for _, name := range RegisteredTypes() {
+		require.Contains(t, schema.Definitions, name)
+	}
+	sel, ok := schema.Definitions[Prefix+"Select"]
+	require.True(t, ok)
+	require.Contains(t, sel.Properties, Prefix+"tags")
+	require.Contains(t, sel.Required, Prefix+"from")
+	require.NotContains(t, sel.Required, Prefix+"tags")
+
+	again, err := GenerateJSONSchema()
+	require.NoError(t, err)
+	require.Equal(t, string(data), string(again))
+}
+
+// matchesJSONSchema reports whether v matches schema, supporting the keywords used for property paths.
+func matchesJSONSchema(definitions map[string]interface{}, schema map[string]interface{}, v interface{}) bool {
+	if r, ok := schema["$ref"].(string); ok {
+		return matchesJSONSchema(definitions, definitions[strings.TrimPrefix(r, "#/definitions/")].(map[string]interface{}), v)
+	}
+	if anyOf, ok := schema["anyOf"].([]interface{}); ok {
+		for _, alt := range anyOf {
+			if matchesJSONSchema(definitions, alt.(map[string]interface{}), v) {
+				return true
+			}
+		}
+		return false
+	}
+	switch schema["type"] {
+	case "string":
+		_, ok := v.(string)
+		return ok
+	case "array":
+		arr, ok := v.([]interface{})
+		if !ok {
+			return false
+		}
+		for _, item := range arr {
+			if !matchesJSONSchema(definitions, schema["items"].(map[string]interface{}), item) {
+				return false
+			}
+		}
+		return true
+	case "object":
+		obj, ok := v.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		props, _ := schema["properties"].(map[string]interface{})
+		for _, key := range schema["required"].([]interface{}) {
+			if _, ok := obj[key.(string)]; !ok {
+				return false
+			}
+		}
+		for key, val := range obj {
+			prop, ok := props[key]
+			if !ok || !matchesJSONSchema(definitions, prop.(map[string]interface{}), val) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+func TestGenerateJSONSchemaPropertyPath(t *testing.T) {
+	data, err := GenerateJSONSchema()
+	require.NoError(t, err)
+	var schema struct {
+		Definitions map[string]interface{} `json:"definitions"`
+	}
+	require.NoError(t, json.Unmarshal(data, &schema))
+	visit := schema.Definitions[Prefix+"Visit"].(map[string]interface{})
+	properties := visit["properties"].(map[string]interface{})[Prefix+"properties"].(map[string]interface{})
+	for _, c := range []struct {
+		name string
+		data string
+	}{
+		{"IRI", `"likes"`},
+		{"IRIs", `["likes", {"@id": "knows"}]`},
+		{"@id", `{"@id": "likes"}`},
+		{"@list", `{"@list": ["knows", {"@id": "likes"}]}`},
+		{"@reverse", `{"@reverse": {"@id": "likes"}}`},
+		{"linkedql:zeroOrMore", `{"linkedql:zeroOrMore": {"@list": ["knows", "likes"]}}`},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			var p PropertyPath
+			require.NoError(t, json.Unmarshal([]byte(c.data), &p))
+			var v interface{}
+			require.NoError(t, json.Unmarshal([]byte(c.data), &v))
+			require.True(t, matchesJSONSchema(schema.Definitions, properties, v))
+			require.False(t, matchesJSONSchema(schema.Definitions, properties, map[string]interface{}{"@value": v}))
+		})
+	}
+}
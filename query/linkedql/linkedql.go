@@ -35,6 +35,9 @@ var _ query.Session = &Session{}
 // Session represents a LinkedQL query processing.
 type Session struct {
 	qs graph.QuadStore
+	// Namespaces are the default namespaces used to expand IRIs of the queries.
+	// Context steps extend them.
+	Namespaces *voc.Namespaces
 }
 
 // NewSession creates a new Session.
@@ -50,6 +53,15 @@ func (s *Session) Execute(ctx context.Context, query string, opt query.Options)
 	if err != nil {
 		return nil, err
 	}
+	if err := Validate(item); err != nil {
+		return nil, err
+	}
+	if err := ResolveMorphisms(item); err != nil {
+		return nil, err
+	}
+	if err := ExpandContextsWith(item, s.Namespaces); err != nil {
+		return nil, err
+	}
 	step, ok := item.(IteratorStep)
 	if !ok {
 		return nil, errors.New("must execute a valid step")
@@ -0,0 +1,104 @@
+package linkedql
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// ResolveMorphisms binds the Follow steps nested in item referring to a morphism by name
+// to the Morphism step of item defining it.
+// Morphisms can be used anywhere in the query they are defined in, regardless of where they are defined.
+func ResolveMorphisms(item RegistryItem) error {
+	morphisms := make(map[string]*Morphism)
+	err := walkItems(item, func(item RegistryItem) error {
+		m, ok := item.(*Morphism)
+		if !ok {
+			return nil
+		}
+		if _, ok := morphisms[m.Name]; ok {
+			return fmt.Errorf("morphism %q is defined more than once", m.Name)
+		}
+		morphisms[m.Name] = m
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return walkItems(item, func(item RegistryItem) error {
+		f, ok := item.(*Follow)
+		if !ok || f.Name == "" {
+			return nil
+		}
+		m, ok := morphisms[f.Name]
+		if !ok {
+			return fmt.Errorf("morphism %q is not defined", f.Name)
+		}
+		f.morphism = m
+		return nil
+	})
+}
+
+// walkItems calls fn for item and all the items nested in it.
+func walkItems(item RegistryItem, fn func(item RegistryItem) error) error {
+	if err := fn(item); err != nil {
+		return err
+	}
+	v := reflect.ValueOf(item)
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+	return walkFields(v, fn)
+}
+
+func walkFields(v reflect.Value, fn func(item RegistryItem) error) error {
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Type().Field(i)
+		fv := v.Field(i)
+		if f.Anonymous && f.Type.Kind() == reflect.Struct {
+			if err := walkFields(fv, fn); err != nil {
+				return err
+			}
+			continue
+		}
+		if f.PkgPath != "" {
+			// unexported
+			continue
+		}
+		switch f.Type.Kind() {
+		case reflect.Interface:
+			if fv.IsNil() {
+				continue
+			}
+			if nested, ok := fv.Interface().(RegistryItem); ok {
+				if err := walkItems(nested, fn); err != nil {
+					return err
+				}
+			}
+		case reflect.Struct:
+			if f.Type != propertyPathType {
+				continue
+			}
+			// property paths may be steps as well
+			if nested, ok := fv.Interface().(PropertyPath).p.(RegistryItem); ok {
+				if err := walkItems(nested, fn); err != nil {
+					return err
+				}
+			}
+		case reflect.Slice:
+			for j := 0; j < fv.Len(); j++ {
+				if nested, ok := fv.Index(j).Interface().(RegistryItem); ok {
+					if err := walkItems(nested, fn); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package linkedql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cayleygraph/cayley/graph/memstore"
+	"github.com/cayleygraph/cayley/query"
+	"github.com/cayleygraph/quad"
+	"github.com/stretchr/testify/require"
+)
+
+var morphismData = []quad.Quad{
+	quad.MakeIRI("alice", "likes", "bob", ""),
+	quad.MakeIRI("bob", "likes", "carol", ""),
+	quad.Make(quad.IRI("bob"), quad.IRI("name"), quad.String("Bob"), nil),
+	quad.Make(quad.IRI("carol"), quad.IRI("name"), quad.String("Carol"), nil),
+}
+
+func TestResolveMorphisms(t *testing.T) {
+	// likedName is defined once and applied to alice and bob
+	step := &Order{
+		From: &Union{
+			From: &Follow{
+				From: &Morphism{
+					From: &Vertex{Values: []quad.Value{quad.IRI("alice")}},
+					Name: "likedName",
+					Path: &Visit{
+						From: &Visit{
+							From:       &Placeholder{},
+							Properties: PropertyPath{PropertyIRIString("likes")},
+						},
+						Properties: PropertyPath{PropertyIRIString("name")},
+					},
+				},
+				Name: "likedName",
+			},
+			Steps: []PathStep{
+				&Follow{
+					From: &Vertex{Values: []quad.Value{quad.IRI("bob")}},
+					Name: "likedName",
+				},
+			},
+		},
+	}
+	require.NoError(t, ResolveMorphisms(step))
+	it, err := step.BuildIterator(memstore.New(morphismData...))
+	require.NoError(t, err)
+	ctx := context.TODO()
+	var results []interface{}
+	for it.Next(ctx) {
+		results = append(results, it.Result())
+	}
+	require.NoError(t, it.Err())
+	require.Equal(t, []interface{}{"Bob", "Carol"}, results)
+}
+
+func TestResolveMorphismsErrors(t *testing.T) {
+	undefined := &Follow{From: &Vertex{}, Name: "undefined"}
+	require.Error(t, ResolveMorphisms(undefined))
+	_, err := undefined.BuildPath(memstore.New())
+	require.Error(t, err)
+
+	twice := &Morphism{
+		From: &Morphism{From: &Vertex{}, Name: "m", Path: &Placeholder{}},
+		Name: "m",
+		Path: &Placeholder{},
+	}
+	require.Error(t, ResolveMorphisms(twice))
+}
+
+func TestExecuteResolvesMorphisms(t *testing.T) {
+	s := NewSession(memstore.New(morphismData...))
+	it, err := s.Execute(context.TODO(), `{
+		"@type": "linkedql:Follow",
+		"linkedql:name": "liked",
+		"linkedql:from": {
+			"@type": "linkedql:Morphism",
+			"linkedql:name": "liked",
+			"linkedql:path": {
+				"@type": "linkedql:Visit",
+				"linkedql:from": {"@type": "linkedql:Placeholder"},
+				"linkedql:properties": "likes"
+			},
+			"linkedql:from": {
+				"@type": "linkedql:Vertex",
+				"linkedql:values": [{"@id": "alice"}]
+			}
+		}
+	}`, query.Options{})
+	require.NoError(t, err)
+	ctx := context.TODO()
+	var results []interface{}
+	for it.Next(ctx) {
+		results = append(results, it.Result())
+	}
+	require.NoError(t, it.Err())
+	require.Equal(t, []interface{}{map[string]string{"@id": "bob"}}, results)
+}
@@ -2,12 +2,24 @@ package linkedql
 
 import (
 	"regexp"
+	"strings"
 
+	"github.com/cayleygraph/cayley/graph"
+	"github.com/cayleygraph/cayley/graph/iterator"
 	"github.com/cayleygraph/cayley/query/path"
 	"github.com/cayleygraph/cayley/query/shape"
 	"github.com/cayleygraph/quad"
 )
 
+func init() {
+	Register(&RegExp{})
+	Register(&Like{})
+	Register(&NotLike{})
+	Register(&Contains{})
+	Register(&StartsWith{})
+	Register(&EndsWith{})
+}
+
 // Operator represents an operator used in a query inside a step (e.g. greater than).
 type Operator interface {
 	RegistryItem
@@ -18,8 +30,9 @@ var _ Operator = (*RegExp)(nil)
 
 // RegExp corresponds to regex().
 type RegExp struct {
-	Pattern     string `json:"pattern"`
-	IncludeIRIs bool   `json:"includeIRIs,omitempty"`
+	Pattern         string `json:"pattern"`
+	IncludeIRIs     bool   `json:"includeIRIs,omitempty"`
+	CaseInsensitive bool   `json:"caseInsensitive,omitempty"`
 }
 
 // Type implements Operator.
@@ -29,26 +42,31 @@ func (s *RegExp) Type() quad.IRI {
 
 // Description implements Operator.
 func (s *RegExp) Description() string {
-	return "RegExp filters out values that do not match given pattern. If includeIRIs is set to true it matches IRIs in addition to literals."
+	return "RegExp filters out values that do not match given pattern. If includeIRIs is set to true it matches IRIs in addition to literals. If caseInsensitive is set to true it matches regardless of letter case."
 }
 
 // Apply implements Operator.
 func (s *RegExp) Apply(p *path.Path) (*path.Path, error) {
-	pattern, err := regexp.Compile(s.Pattern)
+	expr := s.Pattern
+	if s.CaseInsensitive {
+		expr = "(?i)" + expr
+	}
+	pattern, err := regexp.Compile(expr)
 	if err != nil {
 		return nil, err
 	}
 	if s.IncludeIRIs {
 		return p.RegexWithRefs(pattern), nil
 	}
-	return p.RegexWithRefs(pattern), nil
+	return p.Regex(pattern), nil
 }
 
 var _ Operator = (*Like)(nil)
 
 // Like corresponds to like().
 type Like struct {
-	Pattern string `json:"pattern"`
+	Pattern         string `json:"pattern"`
+	CaseInsensitive bool   `json:"caseInsensitive,omitempty"`
 }
 
 // Type implements Operator.
@@ -58,10 +76,155 @@ func (s *Like) Type() quad.IRI {
 
 // Description implements Operator.
 func (s *Like) Description() string {
-	return "Like filters out values that do not match given pattern."
+	return "Like filters out values that do not match given pattern. If caseInsensitive is set to true it matches regardless of letter case."
 }
 
 // Apply implements Operator.
 func (s *Like) Apply(p *path.Path) (*path.Path, error) {
-	return p.Filters(shape.Wildcard{Pattern: s.Pattern}), nil
+	if !s.CaseInsensitive || strings.Trim(s.Pattern, "%") == "" {
+		return p.Filters(shape.Wildcard{Pattern: s.Pattern}), nil
+	}
+	pattern, err := likeRegexp(s.Pattern, true)
+	if err != nil {
+		return nil, err
+	}
+	return p.RegexWithRefs(pattern), nil
+}
+
+// likeRegexp compiles the SQL wildcard pattern to a regular expression.
+func likeRegexp(pattern string, caseInsensitive bool) (*regexp.Regexp, error) {
+	expr := shape.Wildcard{Pattern: pattern}.Regexp()
+	if caseInsensitive {
+		expr = "(?i)" + expr
+	}
+	return regexp.Compile(expr)
+}
+
+var _ Operator = (*NotLike)(nil)
+
+// NotLike corresponds to notLike().
+type NotLike struct {
+	Pattern string `json:"pattern"`
+}
+
+// Type implements Operator.
+func (s *NotLike) Type() quad.IRI {
+	return Prefix + "NotLike"
+}
+
+// Description implements Operator.
+func (s *NotLike) Description() string {
+	return "NotLike filters out values that are not strings or match given pattern."
+}
+
+// Apply implements Operator.
+func (s *NotLike) Apply(p *path.Path) (*path.Path, error) {
+	pattern, err := likeRegexp(s.Pattern, false)
+	if err != nil {
+		return nil, err
+	}
+	return p.Filters(stringFilter(func(s string) bool {
+		return !pattern.MatchString(s)
+	})), nil
+}
+
+var _ shape.ValueFilter = valueFilter(nil)
+
+// valueFilter is a value filter keeping values matching the function.
+type valueFilter func(v quad.Value) bool
+
+func (f valueFilter) BuildIterator(qs graph.QuadStore, it iterator.Shape) iterator.Shape {
+	return iterator.NewValueFilter(qs, it, func(v quad.Value) (bool, error) {
+		return f(v), nil
+	})
+}
+
+var _ shape.ValueFilter = stringFilter(nil)
+
+// stringFilter is a value filter keeping string and language tagged string values matching the function.
+// Values of other types are skipped.
+type stringFilter func(s string) bool
+
+func (f stringFilter) BuildIterator(qs graph.QuadStore, it iterator.Shape) iterator.Shape {
+	return valueFilter(func(v quad.Value) bool {
+		switch v := v.(type) {
+		case quad.String:
+			return f(string(v))
+		case quad.LangString:
+			return f(string(v.Value))
+		}
+		return false
+	}).BuildIterator(qs, it)
+}
+
+var _ Operator = (*Contains)(nil)
+
+// Contains corresponds to contains().
+type Contains struct {
+	Pattern string `json:"pattern"`
+}
+
+// Type implements Operator.
+func (s *Contains) Type() quad.IRI {
+	return Prefix + "Contains"
+}
+
+// Description implements Operator.
+func (s *Contains) Description() string {
+	return "Contains filters out values that are not strings containing given pattern."
+}
+
+// Apply implements Operator.
+func (s *Contains) Apply(p *path.Path) (*path.Path, error) {
+	return p.Filters(stringFilter(func(v string) bool {
+		return strings.Contains(v, s.Pattern)
+	})), nil
+}
+
+var _ Operator = (*StartsWith)(nil)
+
+// StartsWith corresponds to startsWith().
+type StartsWith struct {
+	Pattern string `json:"pattern"`
+}
+
+// Type implements Operator.
+func (s *StartsWith) Type() quad.IRI {
+	return Prefix + "StartsWith"
+}
+
+// Description implements Operator.
+func (s *StartsWith) Description() string {
+	return "StartsWith filters out values that are not strings starting with given pattern."
+}
+
+// Apply implements Operator.
+func (s *StartsWith) Apply(p *path.Path) (*path.Path, error) {
+	return p.Filters(stringFilter(func(v string) bool {
+		return strings.HasPrefix(v, s.Pattern)
+	})), nil
+}
+
+var _ Operator = (*EndsWith)(nil)
+
+// EndsWith corresponds to endsWith().
+type EndsWith struct {
+	Pattern string `json:"pattern"`
+}
+
+// Type implements Operator.
+func (s *EndsWith) Type() quad.IRI {
+	return Prefix + "EndsWith"
+}
+
+// Description implements Operator.
+func (s *EndsWith) Description() string {
+	return "EndsWith filters out values that are not strings ending with given pattern."
+}
+
+// Apply implements Operator.
+func (s *EndsWith) Apply(p *path.Path) (*path.Path, error) {
+	return p.Filters(stringFilter(func(v string) bool {
+		return strings.HasSuffix(v, s.Pattern)
+	})), nil
 }
@@ -2,6 +2,8 @@ package linkedql
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 
 	"github.com/cayleygraph/cayley/graph"
 	"github.com/cayleygraph/cayley/query/path"
@@ -12,6 +14,13 @@ type propertyPathI interface {
 	BuildPath(qs graph.QuadStore) (*path.Path, error)
 }
 
+// propertyFollower is implemented by property paths which can not be
+// represented as a set of properties and are followed hop by hop instead.
+type propertyFollower interface {
+	Follow(qs graph.QuadStore, from *path.Path) (*path.Path, error)
+	FollowReverse(qs graph.QuadStore, from *path.Path) (*path.Path, error)
+}
+
 // PropertyPath is an interface to be used where a path of properties is expected.
 type PropertyPath struct {
 	p propertyPathI
@@ -24,13 +33,49 @@ func (*PropertyPath) Type() string {
 
 // Description implements Step.
 func (*PropertyPath) Description() string {
-	return "PropertyPath is a string, multiple strins or path describing a set of properties"
+	return "PropertyPath is a string, multiple strins or path describing a set of properties. Multiple properties are followed as alternatives in a single hop"
 }
 
 func (p *PropertyPath) BuildPath(qs graph.QuadStore) (*path.Path, error) {
 	return p.p.BuildPath(qs)
 }
 
+// Follow returns the path reached by following the property path from the provided path.
+func (p *PropertyPath) Follow(qs graph.QuadStore, from *path.Path) (*path.Path, error) {
+	if f, ok := p.p.(propertyFollower); ok {
+		return f.Follow(qs, from)
+	}
+	viaPath, err := p.p.BuildPath(qs)
+	if err != nil {
+		return nil, err
+	}
+	return from.Out(viaPath), nil
+}
+
+// isSet reports whether the property path is a set of properties which can be built with BuildPath.
+// Sequences, inverse and repeated property paths can only be followed.
+func (p *PropertyPath) isSet() bool {
+	switch pp := p.p.(type) {
+	case PropertySequence:
+		return len(pp) == 1 && pp[0].isSet()
+	case propertyFollower:
+		return false
+	}
+	return true
+}
+
+// FollowReverse is the same as Follow, but follows the property path in reverse direction.
+func (p *PropertyPath) FollowReverse(qs graph.QuadStore, from *path.Path) (*path.Path, error) {
+	if f, ok := p.p.(propertyFollower); ok {
+		return f.FollowReverse(qs, from)
+	}
+	viaPath, err := p.p.BuildPath(qs)
+	if err != nil {
+		return nil, err
+	}
+	return from.In(viaPath), nil
+}
+
 // UnmarshalJSON implements RawMessage
 func (p *PropertyPath) UnmarshalJSON(data []byte) error {
 	var errors []error
@@ -67,12 +112,51 @@ func (p *PropertyPath) UnmarshalJSON(data []byte) error {
 	}
 	errors = append(errors, err)
 
+	var propertySequence PropertySequence
+	err = json.Unmarshal(data, &propertySequence)
+	if err == nil {
+		p.p = propertySequence
+		return nil
+	}
+	errors = append(errors, err)
+
+	var propertyInverse PropertyInverse
+	err = json.Unmarshal(data, &propertyInverse)
+	if err == nil {
+		p.p = propertyInverse
+		return nil
+	}
+	errors = append(errors, err)
+
+	var propertyZeroOrMore PropertyZeroOrMore
+	err = json.Unmarshal(data, &propertyZeroOrMore)
+	if err == nil {
+		p.p = propertyZeroOrMore
+		return nil
+	}
+	errors = append(errors, err)
+
 	return formatMultiError(errors)
 }
 
 // PropertyIRIs is a slice of property IRIs.
+// Any of the properties is matched when the path is followed.
 type PropertyIRIs []quad.IRI
 
+// UnmarshalJSON implements json.Unmarshaler.
+// Both IRI strings and JSON-LD identifiers are accepted as elements.
+func (p *PropertyIRIs) UnmarshalJSON(data []byte) error {
+	var iris []PropertyIRI
+	if err := json.Unmarshal(data, &iris); err != nil {
+		return err
+	}
+	*p = make(PropertyIRIs, 0, len(iris))
+	for _, iri := range iris {
+		*p = append(*p, quad.IRI(iri))
+	}
+	return nil
+}
+
 // BuildPath implements PropertyPath.
 func (p PropertyIRIs) BuildPath(qs graph.QuadStore) (*path.Path, error) {
 	var values []quad.Value
@@ -98,6 +182,27 @@ func (p PropertyIRIStrings) BuildPath(qs graph.QuadStore) (*path.Path, error) {
 // PropertyIRI is an IRI of a Property
 type PropertyIRI quad.IRI
 
+// UnmarshalJSON implements json.Unmarshaler.
+// The IRI can be provided as a string or as a JSON-LD identifier.
+func (p *PropertyIRI) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*p = PropertyIRI(s)
+		return nil
+	}
+	var id struct {
+		ID *string `json:"@id"`
+	}
+	if err := json.Unmarshal(data, &id); err != nil {
+		return err
+	}
+	if id.ID == nil {
+		return fmt.Errorf("expected a @id key")
+	}
+	*p = PropertyIRI(*id.ID)
+	return nil
+}
+
 // BuildPath implements PropertyPath
 func (p PropertyIRI) BuildPath(qs graph.QuadStore) (*path.Path, error) {
 	vertex := &Vertex{Values: []quad.Value{quad.IRI(p)}}
@@ -112,3 +217,150 @@ func (p PropertyIRIString) BuildPath(qs graph.QuadStore) (*path.Path, error) {
 	iri := PropertyIRI(p)
 	return iri.BuildPath(qs)
 }
+
+// PropertySequence is a sequence of property paths followed one after the other.
+// In JSON it is represented as a JSON-LD list: {"@list": ["likes", "name"]}.
+type PropertySequence []PropertyPath
+
+// BuildPath implements PropertyPath.
+// Only a sequence of a single property path can be used as a set of properties.
+func (p PropertySequence) BuildPath(qs graph.QuadStore) (*path.Path, error) {
+	if len(p) != 1 {
+		return nil, fmt.Errorf("a sequence of %d properties can not be used as a set of properties", len(p))
+	}
+	return p[0].BuildPath(qs)
+}
+
+// Follow implements propertyFollower.
+func (p PropertySequence) Follow(qs graph.QuadStore, from *path.Path) (*path.Path, error) {
+	if len(p) == 0 {
+		return nil, errors.New("empty property sequence")
+	}
+	for i := range p {
+		next, err := p[i].Follow(qs, from)
+		if err != nil {
+			return nil, err
+		}
+		from = next
+	}
+	return from, nil
+}
+
+// FollowReverse implements propertyFollower.
+// The property paths of the sequence are followed in reverse order.
+func (p PropertySequence) FollowReverse(qs graph.QuadStore, from *path.Path) (*path.Path, error) {
+	if len(p) == 0 {
+		return nil, errors.New("empty property sequence")
+	}
+	for i := len(p) - 1; i >= 0; i-- {
+		next, err := p[i].FollowReverse(qs, from)
+		if err != nil {
+			return nil, err
+		}
+		from = next
+	}
+	return from, nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (p *PropertySequence) UnmarshalJSON(data []byte) error {
+	var list struct {
+		List *[]PropertyPath `json:"@list"`
+	}
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	if list.List == nil {
+		return fmt.Errorf("expected a @list key")
+	}
+	*p = *list.List
+	return nil
+}
+
+// PropertyInverse is a property path followed in reverse direction.
+// In JSON it is represented as a JSON-LD reverse property: {"@reverse": "likes"}.
+type PropertyInverse struct {
+	Property PropertyPath
+}
+
+// BuildPath implements PropertyPath.
+func (p PropertyInverse) BuildPath(qs graph.QuadStore) (*path.Path, error) {
+	return nil, errors.New("an inverse property can not be used as a set of properties")
+}
+
+// Follow implements propertyFollower.
+func (p PropertyInverse) Follow(qs graph.QuadStore, from *path.Path) (*path.Path, error) {
+	return p.Property.FollowReverse(qs, from)
+}
+
+// FollowReverse implements propertyFollower.
+func (p PropertyInverse) FollowReverse(qs graph.QuadStore, from *path.Path) (*path.Path, error) {
+	return p.Property.Follow(qs, from)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (p *PropertyInverse) UnmarshalJSON(data []byte) error {
+	var reverse struct {
+		Reverse *PropertyPath `json:"@reverse"`
+	}
+	if err := json.Unmarshal(data, &reverse); err != nil {
+		return err
+	}
+	if reverse.Reverse == nil {
+		return fmt.Errorf("expected a @reverse key")
+	}
+	p.Property = *reverse.Reverse
+	return nil
+}
+
+// PropertyZeroOrMore is a property path followed zero or more times.
+// It resolves to the reflexive-transitive closure of the property path, each node is returned once.
+// In JSON it is represented as {"linkedql:zeroOrMore": "knows"}.
+type PropertyZeroOrMore struct {
+	Property PropertyPath
+}
+
+// BuildPath implements PropertyPath.
+func (p PropertyZeroOrMore) BuildPath(qs graph.QuadStore) (*path.Path, error) {
+	return nil, errors.New("a repeated property can not be used as a set of properties")
+}
+
+// Follow implements propertyFollower.
+func (p PropertyZeroOrMore) Follow(qs graph.QuadStore, from *path.Path) (*path.Path, error) {
+	step, err := p.Property.Follow(qs, path.StartMorphism())
+	if err != nil {
+		return nil, err
+	}
+	return closure(from, step), nil
+}
+
+// FollowReverse implements propertyFollower.
+func (p PropertyZeroOrMore) FollowReverse(qs graph.QuadStore, from *path.Path) (*path.Path, error) {
+	step, err := p.Property.FollowReverse(qs, path.StartMorphism())
+	if err != nil {
+		return nil, err
+	}
+	return closure(from, step), nil
+}
+
+// closure returns the nodes of from and all the nodes reachable from them by repeating step.
+// The recursion depth is not limited: the recursive iterator keeps track of the visited nodes
+// so cycles are followed only once.
+func closure(from, step *path.Path) *path.Path {
+	return from.Or(from.FollowRecursive(step, -1, nil)).Unique()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (p *PropertyZeroOrMore) UnmarshalJSON(data []byte) error {
+	var repeat struct {
+		ZeroOrMore *PropertyPath `json:"linkedql:zeroOrMore"`
+	}
+	if err := json.Unmarshal(data, &repeat); err != nil {
+		return err
+	}
+	if repeat.ZeroOrMore == nil {
+		return fmt.Errorf("expected a linkedql:zeroOrMore key")
+	}
+	p.Property = *repeat.ZeroOrMore
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/cayleygraph/quad"
 )
@@ -57,6 +58,7 @@ var (
 	quadSliceValue = reflect.TypeOf([]quad.Value{})
 	quadIRI        = reflect.TypeOf(quad.IRI(""))
 	quadSliceIRI   = reflect.TypeOf([]quad.IRI{})
+	quadTime       = reflect.TypeOf(quad.Time{})
 )
 
 // Unmarshal attempts to unmarshal an Item or returns error.
@@ -76,8 +78,24 @@ func Unmarshal(data []byte) (RegistryItem, error) {
 		return nil, fmt.Errorf("unsupported item: %q", typ)
 	}
 	item := reflect.New(tp).Elem()
+	if err := unmarshalFields(item, m); err != nil {
+		return nil, err
+	}
+	return item.Addr().Interface().(RegistryItem), nil
+}
+
+// unmarshalFields sets the fields of the struct item from the JSON-LD properties in m.
+func unmarshalFields(item reflect.Value, m map[string]json.RawMessage) error {
+	tp := item.Type()
 	for i := 0; i < tp.NumField(); i++ {
 		f := tp.Field(i)
+		if f.Anonymous && f.Type.Kind() == reflect.Struct {
+			// fields of embedded structs (e.g. aliased steps) are promoted
+			if err := unmarshalFields(item.Field(i), m); err != nil {
+				return err
+			}
+			continue
+		}
 		name := f.Name
 		tag := strings.SplitN(f.Tag.Get("json"), ",", 2)[0]
 		if tag == "-" {
@@ -95,11 +113,11 @@ func Unmarshal(data []byte) (RegistryItem, error) {
 			var a interface{}
 			err := json.Unmarshal(v, &a)
 			if err != nil {
-				return nil, err
+				return err
 			}
 			value, err := parseValue(v)
 			if err != nil {
-				return nil, err
+				return err
 			}
 			fv.Set(reflect.ValueOf(value))
 			continue
@@ -107,13 +125,13 @@ func Unmarshal(data []byte) (RegistryItem, error) {
 			var a []interface{}
 			err := json.Unmarshal(v, &a)
 			if err != nil {
-				return nil, err
+				return err
 			}
 			var values []quad.Value
 			for _, item := range a {
 				value, err := parseValue(item)
 				if err != nil {
-					return nil, err
+					return err
 				}
 				values = append(values, value)
 			}
@@ -123,15 +141,27 @@ func Unmarshal(data []byte) (RegistryItem, error) {
 			var a interface{}
 			err := json.Unmarshal(v, &a)
 			if err != nil {
-				return nil, err
+				return err
 			}
 			s, ok := a.(string)
 			if !ok {
-				return nil, fmt.Errorf("Expected a string but received %v instead", a)
+				return fmt.Errorf("Expected a string but received %v instead", a)
 			}
 			val, err := parseIRI(s)
 			if err != nil {
-				return nil, err
+				return err
+			}
+			fv.Set(reflect.ValueOf(val))
+			continue
+		case quadTime:
+			var a interface{}
+			err := json.Unmarshal(v, &a)
+			if err != nil {
+				return err
+			}
+			val, err := parseTime(a)
+			if err != nil {
+				return err
 			}
 			fv.Set(reflect.ValueOf(val))
 			continue
@@ -139,17 +169,17 @@ func Unmarshal(data []byte) (RegistryItem, error) {
 			var a []interface{}
 			err := json.Unmarshal(v, &a)
 			if err != nil {
-				return nil, err
+				return err
 			}
 			var values []quad.IRI
 			for _, item := range a {
 				s, ok := item.(string)
 				if !ok {
-					return nil, fmt.Errorf("Expected a string but received %v instead", item)
+					return fmt.Errorf("Expected a string but received %v instead", item)
 				}
 				val, err := parseIRI(s)
 				if err != nil {
-					return nil, err
+					return err
 				}
 				values = append(values, val)
 			}
@@ -160,7 +190,7 @@ func Unmarshal(data []byte) (RegistryItem, error) {
 		case reflect.Interface:
 			s, err := Unmarshal(v)
 			if err != nil {
-				return nil, err
+				return err
 			}
 			fv.Set(reflect.ValueOf(s))
 		case reflect.Slice:
@@ -168,19 +198,19 @@ func Unmarshal(data []byte) (RegistryItem, error) {
 			if el.Kind() != reflect.Interface {
 				err := json.Unmarshal(v, fv.Addr().Interface())
 				if err != nil {
-					return nil, err
+					return err
 				}
 			} else {
 				var arr []json.RawMessage
 				if err := json.Unmarshal(v, &arr); err != nil {
-					return nil, err
+					return err
 				}
 				if arr != nil {
 					va := reflect.MakeSlice(f.Type, len(arr), len(arr))
 					for i, v := range arr {
 						s, err := Unmarshal(v)
 						if err != nil {
-							return nil, err
+							return err
 						}
 						va.Index(i).Set(reflect.ValueOf(s))
 					}
@@ -190,11 +220,11 @@ func Unmarshal(data []byte) (RegistryItem, error) {
 		default:
 			err := json.Unmarshal(v, fv.Addr().Interface())
 			if err != nil {
-				return nil, err
+				return err
 			}
 		}
 	}
-	return item.Addr().Interface().(RegistryItem), nil
+	return nil
 }
 
 func parseBNode(s string) (quad.BNode, error) {
@@ -208,6 +238,26 @@ func parseIRI(s string) (quad.IRI, error) {
 	return quad.IRI(s), nil
 }
 
+// parseTime parses a RFC 3339 string or a JSON-LD value typed as a date time.
+func parseTime(a interface{}) (quad.Time, error) {
+	if s, ok := a.(string); ok {
+		t, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			return quad.Time{}, err
+		}
+		return quad.Time(t), nil
+	}
+	lit, err := parseLiteral(a)
+	if err != nil {
+		return quad.Time{}, err
+	}
+	t, ok := timeOf(lit)
+	if !ok {
+		return quad.Time{}, fmt.Errorf("Expected a date time but received %v instead", a)
+	}
+	return quad.Time(t), nil
+}
+
 func parseIdentifier(s string) (quad.Value, error) {
 	bnode, err := parseBNode(s)
 	if err == nil {
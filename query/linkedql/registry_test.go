@@ -2,6 +2,7 @@ package linkedql
 
 import (
 	"testing"
+	"time"
 
 	"github.com/cayleygraph/cayley/graph"
 	"github.com/cayleygraph/cayley/query"
@@ -88,6 +89,176 @@ var unmarshalCases = []struct {
 			},
 		},
 	},
+	{
+		name: "alias",
+		data: `{
+	"@type": "linkedql:Within",
+	"linkedql:from": {
+		"@type": "linkedql:Vertex"
+	},
+	"linkedql:values": [{"@id": "bob"}]
+}`,
+		exp: &Within{
+			Is: Is{
+				From:   &Vertex{},
+				Values: []quad.Value{quad.IRI("bob")},
+			},
+		},
+	},
+	{
+		name: "blank node",
+		data: `{
+	"@type": "linkedql:Vertex",
+	"linkedql:values": [{"@id": "_:b1"}, {"@id": "bob"}]
+}`,
+		exp: &Vertex{
+			Values: []quad.Value{quad.BNode("b1"), quad.IRI("bob")},
+		},
+	},
+	{
+		name: "alternative properties",
+		data: `{
+	"@type": "linkedql:Visit",
+	"linkedql:from": {
+		"@type": "linkedql:Vertex"
+	},
+	"linkedql:properties": [{"@id": "likes"}, "follows"]
+}`,
+		exp: &Visit{
+			From:       &Vertex{},
+			Properties: PropertyPath{PropertyIRIs{quad.IRI("likes"), quad.IRI("follows")}},
+		},
+	},
+	{
+		name: "property identifier",
+		data: `{
+	"@type": "linkedql:Visit",
+	"linkedql:from": {
+		"@type": "linkedql:Vertex"
+	},
+	"linkedql:properties": {"@id": "likes"}
+}`,
+		exp: &Visit{
+			From:       &Vertex{},
+			Properties: PropertyPath{PropertyIRI("likes")},
+		},
+	},
+	{
+		name: "property sequence",
+		data: `{
+	"@type": "linkedql:Visit",
+	"linkedql:from": {
+		"@type": "linkedql:Vertex"
+	},
+	"linkedql:properties": {"@list": ["likes", ["name", "title"]]}
+}`,
+		exp: &Visit{
+			From: &Vertex{},
+			Properties: PropertyPath{PropertySequence{
+				PropertyPath{PropertyIRI("likes")},
+				PropertyPath{PropertyIRIs{quad.IRI("name"), quad.IRI("title")}},
+			}},
+		},
+	},
+	{
+		name: "inverse property",
+		data: `{
+	"@type": "linkedql:Visit",
+	"linkedql:from": {
+		"@type": "linkedql:Vertex"
+	},
+	"linkedql:properties": {"@list": [{"@reverse": "likes"}, "name"]}
+}`,
+		exp: &Visit{
+			From: &Vertex{},
+			Properties: PropertyPath{PropertySequence{
+				PropertyPath{PropertyInverse{PropertyPath{PropertyIRI("likes")}}},
+				PropertyPath{PropertyIRI("name")},
+			}},
+		},
+	},
+	{
+		name: "zero or more property",
+		data: `{
+	"@type": "linkedql:Visit",
+	"linkedql:from": {
+		"@type": "linkedql:Vertex"
+	},
+	"linkedql:properties": {"linkedql:zeroOrMore": "knows"}
+}`,
+		exp: &Visit{
+			From:       &Vertex{},
+			Properties: PropertyPath{PropertyZeroOrMore{PropertyPath{PropertyIRI("knows")}}},
+		},
+	},
+	{
+		name: "date time",
+		data: `{
+	"@type": "linkedql:UpdatedSince",
+	"linkedql:from": {
+		"@type": "linkedql:Vertex"
+	},
+	"linkedql:property": "modifiedAt",
+	"linkedql:since": "2021-01-01T00:00:00Z"
+}`,
+		exp: &UpdatedSince{
+			From:     &Vertex{},
+			Property: PropertyPath{PropertyIRI("modifiedAt")},
+			Since:    quad.Time(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)),
+		},
+	},
+	{
+		name: "graph pattern",
+		data: `{
+	"@type": "linkedql:Match",
+	"linkedql:from": {
+		"@type": "linkedql:Vertex"
+	},
+	"linkedql:graphPattern": {"friend": {"name": "Bob", "age": 32}}
+}`,
+		exp: &Match{
+			From: &Vertex{},
+			GraphPattern: GraphPattern{
+				"friend": map[string]interface{}{"name": "Bob", "age": float64(32)},
+			},
+		},
+	},
+	{
+		name: "iterator step",
+		data: `{
+	"@type": "linkedql:WithTotal",
+	"linkedql:from": {
+		"@type": "linkedql:Paginate",
+		"linkedql:from": {
+			"@type": "linkedql:Vertex"
+		},
+		"linkedql:pageSize": 10
+	}
+}`,
+		exp: &WithTotal{
+			From: &Paginate{
+				From:     &Vertex{},
+				PageSize: 10,
+			},
+		},
+	},
+	{
+		name: "operator",
+		data: `{
+	"@type": "linkedql:Filter",
+	"linkedql:from": {
+		"@type": "linkedql:Vertex"
+	},
+	"linkedql:filter": {
+		"@type": "linkedql:Contains",
+		"linkedql:pattern": "lic"
+	}
+}`,
+		exp: &Filter{
+			From:   &Vertex{},
+			Filter: &Contains{Pattern: "lic"},
+		},
+	},
 }
 
 type TestStep struct {
@@ -1,10 +1,17 @@
 package linkedql
 
 import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
 	"github.com/cayleygraph/cayley/graph"
 	"github.com/cayleygraph/cayley/graph/iterator"
 	"github.com/cayleygraph/cayley/query"
 	"github.com/cayleygraph/cayley/query/path"
+	"github.com/cayleygraph/cayley/query/shape"
 	"github.com/cayleygraph/quad"
 )
 
@@ -18,25 +25,50 @@ func init() {
 	Register(&As{})
 	Register(&Intersect{})
 	Register(&Is{})
+	Register(&Within{})
+	Register(&NotWithin{})
+	Register(&WithinRef{})
 	Register(&Back{})
 	Register(&Both{})
 	Register(&Count{})
 	Register(&Difference{})
+	Register(&Except{})
+	Register(&Not{})
 	Register(&Filter{})
 	Register(&Follow{})
+	Register(&Morphism{})
 	Register(&FollowReverse{})
+	Register(&Reverse{})
+	Register(&FollowRecursive{})
+	Register(&Neighbors{})
 	Register(&Has{})
 	Register(&HasReverse{})
+	Register(&HasAny{})
+	Register(&HasAll{})
+	Register(&HasDatatypeProperty{})
+	Register(&MinDegree{})
+	Register(&MinInDegree{})
+	Register(&UpdatedSince{})
+	Register(&Match{})
+	Register(&Coalesce{})
+	Register(&Context{})
+	Register(&HasRegExp{})
 	Register(&VisitReverse{})
 	Register(&In{})
 	Register(&ReversePropertyNames{})
 	Register(&Labels{})
+	Register(&LabelContext{})
 	Register(&Limit{})
+	Register(&Page{})
 	Register(&PropertyNames{})
+	Register(&PropertyNamesTo{})
 	Register(&Properties{})
 	Register(&ReversePropertyNamesAs{})
 	Register(&PropertyNamesAs{})
 	Register(&ReverseProperties{})
+	Register(&Save{})
+	Register(&SaveReverse{})
+	Register(&SaveOptional{})
 	Register(&Skip{})
 	Register(&Union{})
 	Register(&Unique{})
@@ -47,6 +79,14 @@ func init() {
 	Register(&LessThanEquals{})
 	Register(&GreaterThan{})
 	Register(&GreaterThanEquals{})
+	Register(&Equals{})
+	Register(&NotEquals{})
+	Register(&Between{})
+	Register(&IsIRI{})
+	Register(&IsLiteral{})
+	Register(&IsBlankNode{})
+	Register(&HasDatatype{})
+	Register(&HasLanguage{})
 }
 
 // Step is the tree representation of a call in a Path context.
@@ -227,7 +267,10 @@ var _ PathStep = (*Visit)(nil)
 // Visit corresponds to .view().
 type Visit struct {
 	From       PathStep     `json:"from"`
-	Properties PropertyPath `json:"properties"`
+	Properties PropertyPath `json:"properties" propertyPath:"any"`
+	Labels     []quad.Value `json:"labels,omitempty"`
+	LabelTag   string       `json:"labelTag,omitempty"`
+	Unique     bool         `json:"unique,omitempty"`
 }
 
 // Type implements Step.
@@ -237,7 +280,7 @@ func (s *Visit) Type() quad.IRI {
 
 // Description implements Step.
 func (s *Visit) Description() string {
-	return "resolves to the values of the given property or properties in via of the current objects. If via is a path it's resolved values will be used as properties."
+	return "resolves to the values of the given property or properties in via of the current objects. If via is a path it's resolved values will be used as properties. If labels are provided only quads with one of the labels are traversed. If labelTag is set, the label of the quads traversed is saved to it and only quads with a label are traversed. If unique is set, each value is resolved once."
 }
 
 // BuildIterator implements IteratorStep.
@@ -251,11 +294,28 @@ func (s *Visit) BuildPath(qs graph.QuadStore) (*path.Path, error) {
 	if err != nil {
 		return nil, err
 	}
-	viaPath, err := s.Properties.BuildPath(qs)
+	labeled := s.LabelTag != "" || len(s.Labels) != 0
+	if s.LabelTag != "" {
+		// nil matches any label
+		var labels interface{}
+		if len(s.Labels) != 0 {
+			labels = s.Labels
+		}
+		fromPath = fromPath.LabelContextWithTags([]string{s.LabelTag}, labels)
+	} else if len(s.Labels) != 0 {
+		fromPath = fromPath.LabelContext(s.Labels)
+	}
+	p, err := s.Properties.Follow(qs, fromPath)
 	if err != nil {
 		return nil, err
 	}
-	return fromPath.Out(viaPath), nil
+	if labeled {
+		p = p.LabelContext()
+	}
+	if s.Unique {
+		p = p.Unique()
+	}
+	return p, nil
 }
 
 var _ IteratorStep = (*Out)(nil)
@@ -325,7 +385,7 @@ func (s *Intersect) Type() quad.IRI {
 
 // Description implements Step.
 func (s *Intersect) Description() string {
-	return "resolves to all the same values resolved by the from step and the provided steps."
+	return "resolves to all the same values resolved by the from step and the provided steps. The values are checked against the steps from the smallest to the largest, if sizes can be estimated, and nothing is iterated if the smallest one is empty."
 }
 
 // BuildIterator implements IteratorStep.
@@ -347,7 +407,10 @@ func (s *Intersect) BuildPath(qs graph.QuadStore) (*path.Path, error) {
 		}
 		p = p.And(stepPath)
 	}
-	return p, nil
+	if len(s.Steps) == 0 || joinsOptional(p.Shape()) {
+		return p, nil
+	}
+	return p.Filters(intersectFilter{}), nil
 }
 
 var _ IteratorStep = (*Is)(nil)
@@ -355,8 +418,9 @@ var _ PathStep = (*Is)(nil)
 
 // Is corresponds to .back().
 type Is struct {
-	From   PathStep     `json:"from"`
-	Values []quad.Value `json:"values"`
+	From      PathStep     `json:"from"`
+	Values    []quad.Value `json:"values"`
+	Normalize bool         `json:"normalize,omitempty"`
 }
 
 // Type implements Step.
@@ -366,7 +430,7 @@ func (s *Is) Type() quad.IRI {
 
 // Description implements Step.
 func (s *Is) Description() string {
-	return "resolves to all the values resolved by the from step which are included in provided values."
+	return "resolves to all the values resolved by the from step which are included in provided values. If normalize is set IRIs are compared after expanding registered namespace prefixes, so a prefixed and a full IRI are equal."
 }
 
 // BuildIterator implements IteratorStep.
@@ -380,9 +444,124 @@ func (s *Is) BuildPath(qs graph.QuadStore) (*path.Path, error) {
 	if err != nil {
 		return nil, err
 	}
+	if s.Normalize {
+		return fromPath.Filters(normalizedFilter(s.Values)), nil
+	}
 	return fromPath.Is(s.Values...), nil
 }
 
+// normalizedFilter returns a value filter keeping values equal to one of the provided values
+// once IRIs are expanded with the registered namespaces.
+func normalizedFilter(values []quad.Value) valueFilter {
+	keys := make(map[string]struct{}, len(values))
+	for _, v := range values {
+		keys[normalizedKey(v)] = struct{}{}
+	}
+	return func(v quad.Value) bool {
+		_, ok := keys[normalizedKey(v)]
+		return ok
+	}
+}
+
+// normalizedKey returns the string of v with IRIs expanded to full IRIs.
+func normalizedKey(v quad.Value) string {
+	if iri, ok := v.(quad.IRI); ok {
+		return iri.Full().String()
+	}
+	return v.String()
+}
+
+var _ IteratorStep = (*Within)(nil)
+var _ PathStep = (*Within)(nil)
+
+// Within is an alias for Is.
+type Within struct {
+	Is
+}
+
+// Type implements Step.
+func (s *Within) Type() quad.IRI {
+	return Prefix + "Within"
+}
+
+// Description implements Step.
+func (s *Within) Description() string {
+	return "aliases for Is"
+}
+
+var _ IteratorStep = (*NotWithin)(nil)
+var _ PathStep = (*NotWithin)(nil)
+
+// NotWithin corresponds to .except() with a list of values.
+type NotWithin struct {
+	From   PathStep     `json:"from"`
+	Values []quad.Value `json:"values"`
+}
+
+// Type implements Step.
+func (s *NotWithin) Type() quad.IRI {
+	return Prefix + "NotWithin"
+}
+
+// Description implements Step.
+func (s *NotWithin) Description() string {
+	return "resolves to all the values resolved by the from step which are not included in provided values."
+}
+
+// BuildIterator implements IteratorStep.
+func (s *NotWithin) BuildIterator(qs graph.QuadStore) (query.Iterator, error) {
+	return NewValueIteratorFromPathStep(s, qs)
+}
+
+// BuildPath implements PathStep.
+func (s *NotWithin) BuildPath(qs graph.QuadStore) (*path.Path, error) {
+	fromPath, err := s.From.BuildPath(qs)
+	if err != nil {
+		return nil, err
+	}
+	if len(s.Values) == 0 {
+		return fromPath, nil
+	}
+	return fromPath.Except(path.StartPath(qs, s.Values...)), nil
+}
+
+var _ IteratorStep = (*WithinRef)(nil)
+var _ PathStep = (*WithinRef)(nil)
+
+// WithinRef corresponds to .is() with the values of another path.
+type WithinRef struct {
+	From   PathStep `json:"from"`
+	Source PathStep `json:"source"`
+}
+
+// Type implements Step.
+func (s *WithinRef) Type() quad.IRI {
+	return Prefix + "WithinRef"
+}
+
+// Description implements Step.
+func (s *WithinRef) Description() string {
+	return "resolves to all the values resolved by the from step which are also resolved by the source step."
+}
+
+// BuildIterator implements IteratorStep.
+func (s *WithinRef) BuildIterator(qs graph.QuadStore) (query.Iterator, error) {
+	return NewValueIteratorFromPathStep(s, qs)
+}
+
+// BuildPath implements PathStep.
+func (s *WithinRef) BuildPath(qs graph.QuadStore) (*path.Path, error) {
+	fromPath, err := s.From.BuildPath(qs)
+	if err != nil {
+		return nil, err
+	}
+	sourcePath, err := s.Source.BuildPath(qs)
+	if err != nil {
+		return nil, err
+	}
+	return fromPath.And(sourcePath), nil
+}
+
 var _ IteratorStep = (*Back)(nil)
 var _ PathStep = (*Back)(nil)
 
@@ -458,7 +637,8 @@ var _ PathStep = (*Count)(nil)
 
 // Count corresponds to .count().
 type Count struct {
-	From PathStep `json:"from"`
+	From     PathStep `json:"from"`
+	Distinct bool     `json:"distinct,omitempty"`
 }
 
 // Type implements Step.
@@ -468,7 +648,7 @@ func (s *Count) Type() quad.IRI {
 
 // Description implements Step.
 func (s *Count) Description() string {
-	return "resolves to the number of the resolved values of the from step"
+	return "resolves to the number of the resolved values of the from step. If distinct is set to true, resolves to the number of unique values instead."
 }
 
 // BuildIterator implements IteratorStep.
@@ -482,6 +662,9 @@ func (s *Count) BuildPath(qs graph.QuadStore) (*path.Path, error) {
 	if err != nil {
 		return nil, err
 	}
+	if s.Distinct {
+		fromPath = fromPath.Unique()
+	}
 	return fromPath.Count(), nil
 }
 
@@ -501,7 +684,7 @@ func (s *Difference) Type() quad.IRI {
 
 // Description implements Step.
 func (s *Difference) Description() string {
-	return "resolves to all the values resolved by the from step different then the values resolved by the provided steps. Caution: it might be slow to execute."
+	return "resolves to all the values resolved by the from step different then the values resolved by any of the provided steps. Caution: it might be slow to execute."
 }
 
 // BuildIterator implements IteratorStep.
@@ -515,15 +698,77 @@ func (s *Difference) BuildPath(qs graph.QuadStore) (*path.Path, error) {
 	if err != nil {
 		return nil, err
 	}
-	path := fromPath
+	var union *path.Path
 	for _, step := range s.Steps {
 		p, err := step.BuildPath(qs)
 		if err != nil {
 			return nil, err
 		}
-		path = path.Except(p)
+		if union == nil {
+			union = p
+		} else {
+			union = union.Or(p)
+		}
+	}
+	if union == nil {
+		return fromPath, nil
+	}
+	return fromPath.Except(union), nil
+}
+
+var _ IteratorStep = (*Except)(nil)
+var _ PathStep = (*Except)(nil)
+
+// Except is an alias for Difference.
+type Except struct {
+	Difference
+}
+
+// Type implements Step.
+func (s *Except) Type() quad.IRI {
+	return Prefix + "Except"
+}
+
+// Description implements Step.
+func (s *Except) Description() string {
+	return "aliases for Difference"
+}
+
+var _ IteratorStep = (*Not)(nil)
+var _ PathStep = (*Not)(nil)
+
+// Not corresponds to .not().
+type Not struct {
+	From PathStep `json:"from"`
+	Step PathStep `json:"step"`
+}
+
+// Type implements Step.
+func (s *Not) Type() quad.IRI {
+	return Prefix + "Not"
+}
+
+// Description implements Step.
+func (s *Not) Description() string {
+	return "resolves to all the values resolved by the from step except the ones the given step resolves to when applied to them. The step should start with Placeholder."
+}
+
+// BuildIterator implements IteratorStep.
+func (s *Not) BuildIterator(qs graph.QuadStore) (query.Iterator, error) {
+	return NewValueIteratorFromPathStep(s, qs)
+}
+
+// BuildPath implements PathStep.
+func (s *Not) BuildPath(qs graph.QuadStore) (*path.Path, error) {
+	fromPath, err := s.From.BuildPath(qs)
+	if err != nil {
+		return nil, err
 	}
-	return path, nil
+	stepPath, err := s.Step.BuildPath(qs)
+	if err != nil {
+		return nil, err
+	}
+	return fromPath.Except(fromPath.Follow(stepPath)), nil
 }
 
 var _ IteratorStep = (*Filter)(nil)
@@ -531,8 +776,10 @@ var _ PathStep = (*Filter)(nil)
 
 // Filter corresponds to filter().
 type Filter struct {
-	From   PathStep `json:"from"`
-	Filter Operator `json:"filter"`
+	From     PathStep   `json:"from"`
+	Filter   Operator   `json:"filter" minCardinality:"0"`
+	Operator string     `json:"operator,omitempty"`
+	Steps    []PathStep `json:"steps,omitempty"`
 }
 
 // Type implements Step.
@@ -542,7 +789,7 @@ func (s *Filter) Type() quad.IRI {
 
 // Description implements Step.
 func (s *Filter) Description() string {
-	return "applies constraints to a set of nodes. Can be used to filter values by range or match strings."
+	return "applies constraints to a set of nodes. Can be used to filter values by range or match strings. The constraints are either a filter operator, filter steps starting with Placeholder (e.g. LessThan, GreaterThan), or both. The steps are combined according to operator which is either \"and\" (the default) or \"or\", which resolves to each value matching any of the steps once."
 }
 
 // BuildIterator implements IteratorStep.
@@ -552,11 +799,52 @@ func (s *Filter) BuildIterator(qs graph.QuadStore) (query.Iterator, error) {
 
 // BuildPath implements PathStep.
 func (s *Filter) BuildPath(qs graph.QuadStore) (*path.Path, error) {
-	fromIt, err := s.From.BuildPath(qs)
+	fromPath, err := s.From.BuildPath(qs)
 	if err != nil {
 		return nil, err
 	}
-	return s.Filter.Apply(fromIt)
+	if s.Filter == nil && len(s.Steps) == 0 {
+		return nil, errors.New("Filter expects a filter or steps")
+	}
+	if s.Filter != nil {
+		fromPath, err = s.Filter.Apply(fromPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(s.Steps) == 0 {
+		return fromPath, nil
+	}
+	var or bool
+	switch strings.ToLower(s.Operator) {
+	case "", "and":
+	case "or":
+		or = true
+	default:
+		return nil, fmt.Errorf("Filter expects operator to be \"and\" or \"or\", got %q", s.Operator)
+	}
+	var p *path.Path
+	for i, step := range s.Steps {
+		stepPath, err := step.BuildPath(qs)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case !or && i == 0:
+			p = fromPath.Follow(stepPath)
+		case !or:
+			p = p.Follow(stepPath)
+		case i == 0:
+			p = fromPath.Follow(stepPath)
+		default:
+			p = p.Or(fromPath.Follow(stepPath))
+		}
+	}
+	if or && len(s.Steps) > 1 {
+		// a value matching several steps is resolved by each of them
+		p = p.Unique()
+	}
+	return p, nil
 }
 
 var _ IteratorStep = (*Follow)(nil)
@@ -565,7 +853,10 @@ var _ PathStep = (*Follow)(nil)
 // Follow corresponds to .follow().
 type Follow struct {
 	From     PathStep `json:"from"`
-	Followed PathStep `json:"followed"`
+	Followed PathStep `json:"followed" minCardinality:"0"`
+	Name     string   `json:"name,omitempty"`
+	// morphism is the morphism named Name, see ResolveMorphisms.
+	morphism *Morphism
 }
 
 // Type implements Step.
@@ -575,7 +866,7 @@ func (s *Follow) Type() quad.IRI {
 
 // Description implements Step.
 func (s *Follow) Description() string {
-	return "is the way to use a path prepared with Morphism. Applies the path chain on the morphism object to the current path. Starts as if at the g.M() and follows through the morphism path."
+	return "is the way to use a path prepared with Morphism. Applies the path chain on the morphism object to the current path. Starts as if at the g.M() and follows through the morphism path. The path is either given as followed or is the path of the Morphism of the query with the given name."
 }
 
 // BuildIterator implements IteratorStep.
@@ -589,13 +880,56 @@ func (s *Follow) BuildPath(qs graph.QuadStore) (*path.Path, error) {
 	if err != nil {
 		return nil, err
 	}
-	p, err := s.Followed.BuildPath(qs)
+	followed := s.Followed
+	if s.Name != "" {
+		if followed != nil {
+			return nil, errors.New("Follow expects either followed or name")
+		}
+		if s.morphism == nil {
+			return nil, fmt.Errorf("morphism %q is not resolved", s.Name)
+		}
+		followed = s.morphism.Path
+	}
+	if followed == nil {
+		return nil, errors.New("Follow expects either followed or name")
+	}
+	p, err := followed.BuildPath(qs)
 	if err != nil {
 		return nil, err
 	}
 	return fromPath.Follow(p), nil
 }
 
+var _ IteratorStep = (*Morphism)(nil)
+var _ PathStep = (*Morphism)(nil)
+
+// Morphism corresponds to .morphism().
+type Morphism struct {
+	From PathStep `json:"from"`
+	Name string   `json:"name"`
+	Path PathStep `json:"path"`
+}
+
+// Type implements Step.
+func (s *Morphism) Type() quad.IRI {
+	return Prefix + "Morphism"
+}
+
+// Description implements Step.
+func (s *Morphism) Description() string {
+	return "defines a reusable path with the given name that can be applied with Follow anywhere in the query. The path should start with Placeholder. Resolves to the current entities / values unchanged."
+}
+
+// BuildIterator implements IteratorStep.
+func (s *Morphism) BuildIterator(qs graph.QuadStore) (query.Iterator, error) {
+	return NewValueIteratorFromPathStep(s, qs)
+}
+
+// BuildPath implements PathStep.
+func (s *Morphism) BuildPath(qs graph.QuadStore) (*path.Path, error) {
+	return s.From.BuildPath(qs)
+}
+
 var _ IteratorStep = (*FollowReverse)(nil)
 var _ PathStep = (*FollowReverse)(nil)
 
@@ -633,71 +967,74 @@ func (s *FollowReverse) BuildPath(qs graph.QuadStore) (*path.Path, error) {
 	return fromPath.FollowReverse(p), nil
 }
 
-var _ IteratorStep = (*Has)(nil)
-var _ PathStep = (*Has)(nil)
+var _ IteratorStep = (*Reverse)(nil)
+var _ PathStep = (*Reverse)(nil)
 
-// Has corresponds to .has().
-type Has struct {
-	From     PathStep     `json:"from"`
-	Property PropertyPath `json:"property"`
-	Values   []quad.Value `json:"values"`
+// Reverse corresponds to .reverse().
+type Reverse struct {
+	From PathStep `json:"from"`
+	Step PathStep `json:"step"`
 }
 
 // Type implements Step.
-func (s *Has) Type() quad.IRI {
-	return Prefix + "Has"
+func (s *Reverse) Type() quad.IRI {
+	return Prefix + "Reverse"
 }
 
 // Description implements Step.
-func (s *Has) Description() string {
-	return "filters all paths which are, at this point, on the subject for the given predicate and object, but do not follow the path, merely filter the possible paths. Usually useful for starting with all nodes, or limiting to a subset depending on some predicate/value pair."
+func (s *Reverse) Description() string {
+	return "applies the given step to the values resolved by the from step with the direction of its traversals flipped, so Visit behaves like VisitReverse and VisitReverse like Visit. Filters are applied as is. Steps which can not be reversed, like PropertyNames or Labels, are rejected. The step should start with Placeholder."
 }
 
 // BuildIterator implements IteratorStep.
-func (s *Has) BuildIterator(qs graph.QuadStore) (query.Iterator, error) {
+func (s *Reverse) BuildIterator(qs graph.QuadStore) (query.Iterator, error) {
 	return NewValueIteratorFromPathStep(s, qs)
 }
 
 // BuildPath implements PathStep.
-func (s *Has) BuildPath(qs graph.QuadStore) (*path.Path, error) {
+func (s *Reverse) BuildPath(qs graph.QuadStore) (*path.Path, error) {
 	fromPath, err := s.From.BuildPath(qs)
 	if err != nil {
 		return nil, err
 	}
-	viaPath, err := s.Property.BuildPath(qs)
+	stepPath, err := s.Step.BuildPath(qs)
 	if err != nil {
 		return nil, err
 	}
-	return fromPath.Has(viaPath, s.Values...), nil
+	reversed, err := stepPath.ReverseChecked()
+	if err != nil {
+		return nil, err
+	}
+	return fromPath.Follow(reversed), nil
 }
 
-var _ IteratorStep = (*HasReverse)(nil)
-var _ PathStep = (*HasReverse)(nil)
+var _ IteratorStep = (*FollowRecursive)(nil)
+var _ PathStep = (*FollowRecursive)(nil)
 
-// HasReverse corresponds to .hasR().
-type HasReverse struct {
+// FollowRecursive corresponds to .followRecursive().
+type FollowRecursive struct {
 	From     PathStep     `json:"from"`
 	Property PropertyPath `json:"property"`
-	Values   []quad.Value `json:"values"`
+	MaxDepth int          `json:"maxDepth,omitempty"`
 }
 
 // Type implements Step.
-func (s *HasReverse) Type() quad.IRI {
-	return Prefix + "HasReverse"
+func (s *FollowRecursive) Type() quad.IRI {
+	return Prefix + "FollowRecursive"
 }
 
 // Description implements Step.
-func (s *HasReverse) Description() string {
-	return "is the same as Has, but sets constraint in reverse direction."
+func (s *FollowRecursive) Description() string {
+	return "follows the given property recursively from the current entities / values, ignoring loops. For example, it turns \"parent\" into \"all ancestors\". maxDepth is the maximum number of recursive steps, if omitted defaults to 50 and if set to -1 has no limit."
 }
 
 // BuildIterator implements IteratorStep.
-func (s *HasReverse) BuildIterator(qs graph.QuadStore) (query.Iterator, error) {
+func (s *FollowRecursive) BuildIterator(qs graph.QuadStore) (query.Iterator, error) {
 	return NewValueIteratorFromPathStep(s, qs)
 }
 
 // BuildPath implements PathStep.
-func (s *HasReverse) BuildPath(qs graph.QuadStore) (*path.Path, error) {
+func (s *FollowRecursive) BuildPath(qs graph.QuadStore) (*path.Path, error) {
 	fromPath, err := s.From.BuildPath(qs)
 	if err != nil {
 		return nil, err
@@ -706,105 +1043,699 @@ func (s *HasReverse) BuildPath(qs graph.QuadStore) (*path.Path, error) {
 	if err != nil {
 		return nil, err
 	}
-	return fromPath.HasReverse(viaPath, s.Values...), nil
+	return fromPath.FollowRecursive(path.StartMorphism().Out(viaPath), s.MaxDepth, nil), nil
 }
 
-var _ IteratorStep = (*VisitReverse)(nil)
-var _ PathStep = (*VisitReverse)(nil)
+var _ IteratorStep = (*Neighbors)(nil)
+var _ PathStep = (*Neighbors)(nil)
 
-// VisitReverse corresponds to .viewReverse().
-type VisitReverse struct {
-	From       PathStep     `json:"from"`
-	Properties PropertyPath `json:"properties"`
+// Neighbors corresponds to .neighbors().
+type Neighbors struct {
+	From         PathStep     `json:"from"`
+	Via          PropertyPath `json:"via"`
+	MaxHops      int          `json:"maxHops"`
+	IncludeStart bool         `json:"includeStart,omitempty"`
 }
 
 // Type implements Step.
-func (s *VisitReverse) Type() quad.IRI {
-	return Prefix + "VisitReverse"
+func (s *Neighbors) Type() quad.IRI {
+	return Prefix + "Neighbors"
 }
 
 // Description implements Step.
-func (s *VisitReverse) Description() string {
-	return "is the inverse of View. Starting with the nodes in `path` on the object, follow the quads with predicates defined by `predicatePath` to their subjects."
+func (s *Neighbors) Description() string {
+	return "Neighbors resolves to the distinct entities reachable from the current entities by following the given properties up to maxHops times, ignoring loops. The current entities are excluded unless includeStart is set to true."
 }
 
 // BuildIterator implements IteratorStep.
-func (s *VisitReverse) BuildIterator(qs graph.QuadStore) (query.Iterator, error) {
+func (s *Neighbors) BuildIterator(qs graph.QuadStore) (query.Iterator, error) {
 	return NewValueIteratorFromPathStep(s, qs)
 }
 
 // BuildPath implements PathStep.
-func (s *VisitReverse) BuildPath(qs graph.QuadStore) (*path.Path, error) {
+func (s *Neighbors) BuildPath(qs graph.QuadStore) (*path.Path, error) {
+	if s.MaxHops <= 0 {
+		return nil, errors.New("Neighbors requires maxHops to be positive")
+	}
 	fromPath, err := s.From.BuildPath(qs)
 	if err != nil {
 		return nil, err
 	}
-	viaPath, err := s.Properties.BuildPath(qs)
+	startPath, err := s.From.BuildPath(qs)
 	if err != nil {
 		return nil, err
 	}
-	return fromPath.In(viaPath), nil
-}
-
-var _ IteratorStep = (*In)(nil)
-var _ PathStep = (*In)(nil)
-
-// In is an alias for ViewReverse.
-type In struct {
-	VisitReverse
-}
-
-// Type implements Step.
-func (s *In) Type() quad.IRI {
-	return Prefix + "In"
-}
-
-// Description implements Step.
-func (s *In) Description() string {
-	return "aliases for ViewReverse"
+	viaPath, err := s.Via.BuildPath(qs)
+	if err != nil {
+		return nil, err
+	}
+	reached := fromPath.FollowRecursive(path.StartMorphism().Out(viaPath), s.MaxHops, nil)
+	if s.IncludeStart {
+		return reached.Or(startPath).Unique(), nil
+	}
+	return reached.Except(startPath).Unique(), nil
 }
 
-var _ IteratorStep = (*ReversePropertyNames)(nil)
-var _ PathStep = (*ReversePropertyNames)(nil)
+var _ IteratorStep = (*Has)(nil)
+var _ PathStep = (*Has)(nil)
 
-// ReversePropertyNames corresponds to .reversePropertyNames().
-type ReversePropertyNames struct {
-	From PathStep `json:"from"`
+// Has corresponds to .has().
+type Has struct {
+	From     PathStep     `json:"from"`
+	Property PropertyPath `json:"property" propertyPath:"any"`
+	Values   []quad.Value `json:"values"`
+	// Operator compares the property values with Value instead of matching Values.
+	// One of "gt", "gte", "lt", "lte" or "eq".
+	Operator string     `json:"operator,omitempty"`
+	Value    quad.Value `json:"value,omitempty" minCardinality:"0"`
 }
 
 // Type implements Step.
-func (s *ReversePropertyNames) Type() quad.IRI {
-	return Prefix + "ReversePropertyNames"
+func (s *Has) Type() quad.IRI {
+	return Prefix + "Has"
 }
 
 // Description implements Step.
-func (s *ReversePropertyNames) Description() string {
-	return "gets the list of predicates that are pointing in to a node."
+func (s *Has) Description() string {
+	return "filters all paths which are, at this point, on the subject for the given predicate and object, but do not follow the path, merely filter the possible paths. Usually useful for starting with all nodes, or limiting to a subset depending on some predicate/value pair. If an operator is provided, the property values are compared with the value using it instead."
 }
 
 // BuildIterator implements IteratorStep.
-func (s *ReversePropertyNames) BuildIterator(qs graph.QuadStore) (query.Iterator, error) {
+func (s *Has) BuildIterator(qs graph.QuadStore) (query.Iterator, error) {
 	return NewValueIteratorFromPathStep(s, qs)
 }
 
 // BuildPath implements PathStep.
-func (s *ReversePropertyNames) BuildPath(qs graph.QuadStore) (*path.Path, error) {
+func (s *Has) BuildPath(qs graph.QuadStore) (*path.Path, error) {
 	fromPath, err := s.From.BuildPath(qs)
 	if err != nil {
 		return nil, err
 	}
-	return fromPath.InPredicates(), nil
-}
-
-var _ IteratorStep = (*Labels)(nil)
-var _ PathStep = (*Labels)(nil)
-
-// Labels corresponds to .labels().
-type Labels struct {
-	From PathStep `json:"from"`
-}
-
-// Type implements Step.
+	values := s.Values
+	var filter shape.ValueFilter
+	if s.Operator != "" {
+		if s.Value == nil {
+			return nil, errors.New("Has requires a value when an operator is provided")
+		}
+		if s.Operator == "eq" {
+			values = []quad.Value{s.Value}
+		} else {
+			op, err := comparisonOperator(s.Operator)
+			if err != nil {
+				return nil, err
+			}
+			filter = comparisonFilter{op: op, value: s.Value}
+		}
+	}
+	if !s.Property.isSet() {
+		// the entities from which the property path reaches the values
+		valuesPath := path.StartPath(qs, values...)
+		if filter != nil {
+			valuesPath = valuesPath.Filters(filter)
+		}
+		p, err := s.Property.FollowReverse(qs, valuesPath)
+		if err != nil {
+			return nil, err
+		}
+		return fromPath.And(p), nil
+	}
+	viaPath, err := s.Property.BuildPath(qs)
+	if err != nil {
+		return nil, err
+	}
+	if filter != nil {
+		return fromPath.HasFilter(viaPath, false, filter), nil
+	}
+	return fromPath.Has(viaPath, values...), nil
+}
+
+var _ IteratorStep = (*HasReverse)(nil)
+var _ PathStep = (*HasReverse)(nil)
+
+// HasReverse corresponds to .hasR().
+type HasReverse struct {
+	From     PathStep     `json:"from"`
+	Property PropertyPath `json:"property" propertyPath:"any"`
+	Values   []quad.Value `json:"values"`
+}
+
+// Type implements Step.
+func (s *HasReverse) Type() quad.IRI {
+	return Prefix + "HasReverse"
+}
+
+// Description implements Step.
+func (s *HasReverse) Description() string {
+	return "is the same as Has, but sets constraint in reverse direction."
+}
+
+// BuildIterator implements IteratorStep.
+func (s *HasReverse) BuildIterator(qs graph.QuadStore) (query.Iterator, error) {
+	return NewValueIteratorFromPathStep(s, qs)
+}
+
+// BuildPath implements PathStep.
+func (s *HasReverse) BuildPath(qs graph.QuadStore) (*path.Path, error) {
+	fromPath, err := s.From.BuildPath(qs)
+	if err != nil {
+		return nil, err
+	}
+	if !s.Property.isSet() {
+		// the entities the property path reaches from the values
+		p, err := s.Property.Follow(qs, path.StartPath(qs, s.Values...))
+		if err != nil {
+			return nil, err
+		}
+		return fromPath.And(p), nil
+	}
+	viaPath, err := s.Property.BuildPath(qs)
+	if err != nil {
+		return nil, err
+	}
+	return fromPath.HasReverse(viaPath, s.Values...), nil
+}
+
+var _ IteratorStep = (*HasAny)(nil)
+var _ PathStep = (*HasAny)(nil)
+
+// HasAny corresponds to .has() with multiple properties.
+type HasAny struct {
+	From       PathStep       `json:"from"`
+	Properties []PropertyPath `json:"properties" minCardinality:"1"`
+	Values     []quad.Value   `json:"values"`
+}
+
+// Type implements Step.
+func (s *HasAny) Type() quad.IRI {
+	return Prefix + "HasAny"
+}
+
+// Description implements Step.
+func (s *HasAny) Description() string {
+	return "is the same as Has, but keeps the current entities having any of the given properties with one of the given values. If no values are provided keeps the entities having any of the properties. Like Has, an entity is resolved once for each matching quad."
+}
+
+// BuildIterator implements IteratorStep.
+func (s *HasAny) BuildIterator(qs graph.QuadStore) (query.Iterator, error) {
+	return NewValueIteratorFromPathStep(s, qs)
+}
+
+// BuildPath implements PathStep.
+func (s *HasAny) BuildPath(qs graph.QuadStore) (*path.Path, error) {
+	fromPath, err := s.From.BuildPath(qs)
+	if err != nil {
+		return nil, err
+	}
+	if len(s.Properties) == 0 {
+		return nil, errors.New("HasAny requires at least one property")
+	}
+	var viaPath *path.Path
+	for _, property := range s.Properties {
+		p, err := property.BuildPath(qs)
+		if err != nil {
+			return nil, err
+		}
+		if viaPath == nil {
+			viaPath = p
+		} else {
+			viaPath = viaPath.Or(p)
+		}
+	}
+	return fromPath.Has(viaPath, s.Values...), nil
+}
+
+var _ IteratorStep = (*HasAll)(nil)
+var _ PathStep = (*HasAll)(nil)
+
+// HasAll corresponds to .hasAll().
+type HasAll struct {
+	From     PathStep     `json:"from"`
+	Property PropertyPath `json:"property"`
+	Values   []quad.Value `json:"values" minCardinality:"1"`
+}
+
+// Type implements Step.
+func (s *HasAll) Type() quad.IRI {
+	return Prefix + "HasAll"
+}
+
+// Description implements Step.
+func (s *HasAll) Description() string {
+	return "is the same as Has, but keeps the current entities having the given property with every one of the given values."
+}
+
+// BuildIterator implements IteratorStep.
+func (s *HasAll) BuildIterator(qs graph.QuadStore) (query.Iterator, error) {
+	return NewValueIteratorFromPathStep(s, qs)
+}
+
+// BuildPath implements PathStep.
+func (s *HasAll) BuildPath(qs graph.QuadStore) (*path.Path, error) {
+	fromPath, err := s.From.BuildPath(qs)
+	if err != nil {
+		return nil, err
+	}
+	if len(s.Values) == 0 {
+		return nil, errors.New("HasAll requires at least one value")
+	}
+	viaPath, err := s.Property.BuildPath(qs)
+	if err != nil {
+		return nil, err
+	}
+	p := fromPath
+	for _, value := range s.Values {
+		p = p.Has(viaPath, value)
+	}
+	return p, nil
+}
+
+var _ IteratorStep = (*HasDatatypeProperty)(nil)
+var _ PathStep = (*HasDatatypeProperty)(nil)
+
+// HasDatatypeProperty corresponds to .hasDatatypeProperty().
+type HasDatatypeProperty struct {
+	From     PathStep     `json:"from"`
+	Property PropertyPath `json:"property"`
+	Datatype quad.IRI     `json:"datatype"`
+}
+
+// Type implements Step.
+func (s *HasDatatypeProperty) Type() quad.IRI {
+	return Prefix + "HasDatatypeProperty"
+}
+
+// Description implements Step.
+func (s *HasDatatypeProperty) Description() string {
+	return "is the same as Has, but keeps the current entities having the given property with a value of the given datatype, regardless of the value."
+}
+
+// BuildIterator implements IteratorStep.
+func (s *HasDatatypeProperty) BuildIterator(qs graph.QuadStore) (query.Iterator, error) {
+	return NewValueIteratorFromPathStep(s, qs)
+}
+
+// BuildPath implements PathStep.
+func (s *HasDatatypeProperty) BuildPath(qs graph.QuadStore) (*path.Path, error) {
+	fromPath, err := s.From.BuildPath(qs)
+	if err != nil {
+		return nil, err
+	}
+	viaPath, err := s.Property.BuildPath(qs)
+	if err != nil {
+		return nil, err
+	}
+	return fromPath.HasFilter(viaPath, false, datatypeFilter(s.Datatype)), nil
+}
+
+var _ IteratorStep = (*MinDegree)(nil)
+var _ PathStep = (*MinDegree)(nil)
+
+// MinDegree corresponds to .minDegree().
+type MinDegree struct {
+	From     PathStep     `json:"from"`
+	Property PropertyPath `json:"property"`
+	Count    int          `json:"count"`
+}
+
+// Type implements Step.
+func (s *MinDegree) Type() quad.IRI {
+	return Prefix + "MinDegree"
+}
+
+// Description implements Step.
+func (s *MinDegree) Description() string {
+	return "keeps the current entities having at least count outgoing edges of the given property."
+}
+
+// BuildIterator implements IteratorStep.
+func (s *MinDegree) BuildIterator(qs graph.QuadStore) (query.Iterator, error) {
+	return NewValueIteratorFromPathStep(s, qs)
+}
+
+// BuildPath implements PathStep.
+func (s *MinDegree) BuildPath(qs graph.QuadStore) (*path.Path, error) {
+	fromPath, err := s.From.BuildPath(qs)
+	if err != nil {
+		return nil, err
+	}
+	viaPath, err := s.Property.BuildPath(qs)
+	if err != nil {
+		return nil, err
+	}
+	if s.Count <= 0 {
+		return fromPath, nil
+	}
+	if s.Count == 1 {
+		return fromPath.Has(viaPath), nil
+	}
+	return fromPath.Filters(degreeFilter{via: viaPath, min: s.Count}), nil
+}
+
+var _ IteratorStep = (*MinInDegree)(nil)
+var _ PathStep = (*MinInDegree)(nil)
+
+// MinInDegree corresponds to .minInDegree().
+type MinInDegree struct {
+	From     PathStep     `json:"from"`
+	Property PropertyPath `json:"property"`
+	Count    int          `json:"count"`
+}
+
+// Type implements Step.
+func (s *MinInDegree) Type() quad.IRI {
+	return Prefix + "MinInDegree"
+}
+
+// Description implements Step.
+func (s *MinInDegree) Description() string {
+	return "is the same as MinDegree, but keeps the current entities having at least count incoming edges of the given property."
+}
+
+// BuildIterator implements IteratorStep.
+func (s *MinInDegree) BuildIterator(qs graph.QuadStore) (query.Iterator, error) {
+	return NewValueIteratorFromPathStep(s, qs)
+}
+
+// BuildPath implements PathStep.
+func (s *MinInDegree) BuildPath(qs graph.QuadStore) (*path.Path, error) {
+	fromPath, err := s.From.BuildPath(qs)
+	if err != nil {
+		return nil, err
+	}
+	viaPath, err := s.Property.BuildPath(qs)
+	if err != nil {
+		return nil, err
+	}
+	if s.Count <= 0 {
+		return fromPath, nil
+	}
+	if s.Count == 1 {
+		return fromPath.HasReverse(viaPath), nil
+	}
+	return fromPath.Filters(degreeFilter{via: viaPath, min: s.Count, reverse: true}), nil
+}
+
+var _ IteratorStep = (*UpdatedSince)(nil)
+var _ PathStep = (*UpdatedSince)(nil)
+
+// UpdatedSince corresponds to .updatedSince().
+type UpdatedSince struct {
+	From     PathStep     `json:"from"`
+	Property PropertyPath `json:"property"`
+	Since    quad.Time    `json:"since"`
+}
+
+// Type implements Step.
+func (s *UpdatedSince) Type() quad.IRI {
+	return Prefix + "UpdatedSince"
+}
+
+// Description implements Step.
+func (s *UpdatedSince) Description() string {
+	return "keeps the current entities having the given property with a date time value after since. Values which are not date times are ignored."
+}
+
+// BuildIterator implements IteratorStep.
+func (s *UpdatedSince) BuildIterator(qs graph.QuadStore) (query.Iterator, error) {
+	return NewValueIteratorFromPathStep(s, qs)
+}
+
+// BuildPath implements PathStep.
+func (s *UpdatedSince) BuildPath(qs graph.QuadStore) (*path.Path, error) {
+	fromPath, err := s.From.BuildPath(qs)
+	if err != nil {
+		return nil, err
+	}
+	viaPath, err := s.Property.BuildPath(qs)
+	if err != nil {
+		return nil, err
+	}
+	since := time.Time(s.Since)
+	return fromPath.HasFilter(viaPath, false, valueFilter(func(v quad.Value) bool {
+		t, ok := timeOf(v)
+		return ok && t.After(since)
+	})), nil
+}
+
+var _ IteratorStep = (*Match)(nil)
+var _ PathStep = (*Match)(nil)
+
+// Match corresponds to .match().
+type Match struct {
+	From         PathStep     `json:"from"`
+	GraphPattern GraphPattern `json:"graphPattern"`
+}
+
+// Type implements Step.
+func (s *Match) Type() quad.IRI {
+	return Prefix + "Match"
+}
+
+// Description implements Step.
+func (s *Match) Description() string {
+	return "filters entities by a JSON-LD like graph pattern. @id restricts the entities to the given identifiers and every other key is a property the entities must have. A property value can be a literal, a nested pattern the property value must match, or an array of values of which any must match. @reverse holds properties linking other entities to the entities and @not a pattern the entities must not match."
+}
+
+// BuildIterator implements IteratorStep.
+func (s *Match) BuildIterator(qs graph.QuadStore) (query.Iterator, error) {
+	return NewValueIteratorFromPathStep(s, qs)
+}
+
+// BuildPath implements PathStep.
+func (s *Match) BuildPath(qs graph.QuadStore) (*path.Path, error) {
+	fromPath, err := s.From.BuildPath(qs)
+	if err != nil {
+		return nil, err
+	}
+	return s.GraphPattern.BuildPath(qs, fromPath)
+}
+
+var _ IteratorStep = (*Coalesce)(nil)
+var _ PathStep = (*Coalesce)(nil)
+
+// Coalesce corresponds to .coalesce().
+type Coalesce struct {
+	From       PathStep       `json:"from"`
+	Properties []PropertyPath `json:"properties" minCardinality:"1"`
+}
+
+// Type implements Step.
+func (s *Coalesce) Type() quad.IRI {
+	return Prefix + "Coalesce"
+}
+
+// Description implements Step.
+func (s *Coalesce) Description() string {
+	return "resolves for each of the current entities to the values of the first of the given properties the entity has."
+}
+
+// BuildIterator implements IteratorStep.
+func (s *Coalesce) BuildIterator(qs graph.QuadStore) (query.Iterator, error) {
+	return NewValueIteratorFromPathStep(s, qs)
+}
+
+// BuildPath implements PathStep.
+func (s *Coalesce) BuildPath(qs graph.QuadStore) (*path.Path, error) {
+	fromPath, err := s.From.BuildPath(qs)
+	if err != nil {
+		return nil, err
+	}
+	if len(s.Properties) == 0 {
+		return nil, errors.New("Coalesce requires at least one property")
+	}
+	var (
+		p *path.Path
+		// visited are the properties visited so far
+		visited *path.Path
+	)
+	for _, property := range s.Properties {
+		viaPath, err := property.BuildPath(qs)
+		if err != nil {
+			return nil, err
+		}
+		if visited == nil {
+			p = fromPath.Out(viaPath)
+			visited = viaPath
+			continue
+		}
+		// only the entities having none of the former properties
+		p = p.Or(fromPath.Except(fromPath.Has(visited)).Out(viaPath))
+		visited = visited.Or(viaPath)
+	}
+	return p, nil
+}
+
+var _ IteratorStep = (*Context)(nil)
+var _ PathStep = (*Context)(nil)
+
+// Context corresponds to .context().
+type Context struct {
+	From  PathStep          `json:"from"`
+	Rules map[string]string `json:"rules"`
+}
+
+// Type implements Step.
+func (s *Context) Type() quad.IRI {
+	return Prefix + "Context"
+}
+
+// Description implements Step.
+func (s *Context) Description() string {
+	return "resolves to the same values as from and defines terms for the whole query. Each rule maps a term to an IRI. The term can be used instead of the IRI or as a prefix of IRIs, like ex:alice for a rule mapping ex to http://example.org/."
+}
+
+// BuildIterator implements IteratorStep.
+func (s *Context) BuildIterator(qs graph.QuadStore) (query.Iterator, error) {
+	return NewValueIteratorFromPathStep(s, qs)
+}
+
+// BuildPath implements PathStep.
+func (s *Context) BuildPath(qs graph.QuadStore) (*path.Path, error) {
+	return s.From.BuildPath(qs)
+}
+
+var _ IteratorStep = (*HasRegExp)(nil)
+var _ PathStep = (*HasRegExp)(nil)
+
+// HasRegExp corresponds to .has() with a regular expression.
+type HasRegExp struct {
+	From     PathStep     `json:"from"`
+	Property PropertyPath `json:"property"`
+	Pattern  string       `json:"pattern"`
+}
+
+// Type implements Step.
+func (s *HasRegExp) Type() quad.IRI {
+	return Prefix + "HasRegExp"
+}
+
+// Description implements Step.
+func (s *HasRegExp) Description() string {
+	return "filters all paths which have a value of the given property matching the given pattern. Both literals and IRIs are matched. An entity is matched once for every matching value."
+}
+
+// BuildIterator implements IteratorStep.
+func (s *HasRegExp) BuildIterator(qs graph.QuadStore) (query.Iterator, error) {
+	return NewValueIteratorFromPathStep(s, qs)
+}
+
+// BuildPath implements PathStep.
+func (s *HasRegExp) BuildPath(qs graph.QuadStore) (*path.Path, error) {
+	pattern, err := regexp.Compile(s.Pattern)
+	if err != nil {
+		return nil, err
+	}
+	fromPath, err := s.From.BuildPath(qs)
+	if err != nil {
+		return nil, err
+	}
+	viaPath, err := s.Property.BuildPath(qs)
+	if err != nil {
+		return nil, err
+	}
+	return fromPath.HasFilter(viaPath, false, shape.Regexp{Re: pattern, Refs: true}), nil
+}
+
+var _ IteratorStep = (*VisitReverse)(nil)
+var _ PathStep = (*VisitReverse)(nil)
+
+// VisitReverse corresponds to .viewReverse().
+type VisitReverse struct {
+	From        PathStep     `json:"from"`
+	Properties  PropertyPath `json:"properties" propertyPath:"any"`
+	PropertyTag string       `json:"propertyTag,omitempty"`
+}
+
+// Type implements Step.
+func (s *VisitReverse) Type() quad.IRI {
+	return Prefix + "VisitReverse"
+}
+
+// Description implements Step.
+func (s *VisitReverse) Description() string {
+	return "is the inverse of View. Starting with the nodes in `path` on the object, follow the quads with predicates defined by `predicatePath` to their subjects. If propertyTag is set, the predicate followed is saved to it."
+}
+
+// BuildIterator implements IteratorStep.
+func (s *VisitReverse) BuildIterator(qs graph.QuadStore) (query.Iterator, error) {
+	return NewValueIteratorFromPathStep(s, qs)
+}
+
+// BuildPath implements PathStep.
+func (s *VisitReverse) BuildPath(qs graph.QuadStore) (*path.Path, error) {
+	fromPath, err := s.From.BuildPath(qs)
+	if err != nil {
+		return nil, err
+	}
+	if s.PropertyTag == "" {
+		return s.Properties.FollowReverse(qs, fromPath)
+	}
+	viaPath, err := s.Properties.BuildPath(qs)
+	if err != nil {
+		return nil, err
+	}
+	return fromPath.InWithTags([]string{s.PropertyTag}, viaPath), nil
+}
+
+var _ IteratorStep = (*In)(nil)
+var _ PathStep = (*In)(nil)
+
+// In is an alias for ViewReverse.
+type In struct {
+	VisitReverse
+}
+
+// Type implements Step.
+func (s *In) Type() quad.IRI {
+	return Prefix + "In"
+}
+
+// Description implements Step.
+func (s *In) Description() string {
+	return "aliases for ViewReverse"
+}
+
+var _ IteratorStep = (*ReversePropertyNames)(nil)
+var _ PathStep = (*ReversePropertyNames)(nil)
+
+// ReversePropertyNames corresponds to .reversePropertyNames().
+type ReversePropertyNames struct {
+	From PathStep `json:"from"`
+}
+
+// Type implements Step.
+func (s *ReversePropertyNames) Type() quad.IRI {
+	return Prefix + "ReversePropertyNames"
+}
+
+// Description implements Step.
+func (s *ReversePropertyNames) Description() string {
+	return "gets the list of predicates that are pointing in to a node."
+}
+
+// BuildIterator implements IteratorStep.
+func (s *ReversePropertyNames) BuildIterator(qs graph.QuadStore) (query.Iterator, error) {
+	return NewValueIteratorFromPathStep(s, qs)
+}
+
+// BuildPath implements PathStep.
+func (s *ReversePropertyNames) BuildPath(qs graph.QuadStore) (*path.Path, error) {
+	fromPath, err := s.From.BuildPath(qs)
+	if err != nil {
+		return nil, err
+	}
+	return fromPath.InPredicates(), nil
+}
+
+var _ IteratorStep = (*Labels)(nil)
+var _ PathStep = (*Labels)(nil)
+
+// Labels corresponds to .labels().
+type Labels struct {
+	From PathStep `json:"from"`
+}
+
+// Type implements Step.
 func (s *Labels) Type() quad.IRI {
 	return Prefix + "Labels"
 }
@@ -828,6 +1759,42 @@ func (s *Labels) BuildPath(qs graph.QuadStore) (*path.Path, error) {
 	return fromPath.Labels(), nil
 }
 
+var _ IteratorStep = (*LabelContext)(nil)
+var _ PathStep = (*LabelContext)(nil)
+
+// LabelContext corresponds to .labelContext().
+type LabelContext struct {
+	From   PathStep     `json:"from"`
+	Labels []quad.Value `json:"labels"`
+}
+
+// Type implements Step.
+func (s *LabelContext) Type() quad.IRI {
+	return Prefix + "LabelContext"
+}
+
+// Description implements Step.
+func (s *LabelContext) Description() string {
+	return "restricts the following steps (such as Visit, Has) to only traverse quads with one of the given labels. If no labels are provided, restores traversal of quads with any label."
+}
+
+// BuildIterator implements IteratorStep.
+func (s *LabelContext) BuildIterator(qs graph.QuadStore) (query.Iterator, error) {
+	return NewValueIteratorFromPathStep(s, qs)
+}
+
+// BuildPath implements PathStep.
+func (s *LabelContext) BuildPath(qs graph.QuadStore) (*path.Path, error) {
+	fromPath, err := s.From.BuildPath(qs)
+	if err != nil {
+		return nil, err
+	}
+	if len(s.Labels) == 0 {
+		return fromPath.LabelContext(), nil
+	}
+	return fromPath.LabelContext(s.Labels), nil
+}
+
 var _ IteratorStep = (*Limit)(nil)
 var _ PathStep = (*Limit)(nil)
 
@@ -844,7 +1811,7 @@ func (s *Limit) Type() quad.IRI {
 
 // Description implements Step.
 func (s *Limit) Description() string {
-	return "limits a number of nodes for current path."
+	return "limits a number of nodes for current path. If limit is 0 or negative, all the nodes are returned."
 }
 
 // BuildIterator implements IteratorStep.
@@ -858,9 +1825,53 @@ func (s *Limit) BuildPath(qs graph.QuadStore) (*path.Path, error) {
 	if err != nil {
 		return nil, err
 	}
+	if s.Limit <= 0 {
+		// no limit
+		return fromPath, nil
+	}
 	return fromPath.Limit(s.Limit), nil
 }
 
+var _ IteratorStep = (*Page)(nil)
+var _ PathStep = (*Page)(nil)
+
+// Page corresponds to .page().
+type Page struct {
+	From   PathStep `json:"from"`
+	Number int64    `json:"number"`
+	Size   int64    `json:"size"`
+}
+
+// Type implements Step.
+func (s *Page) Type() quad.IRI {
+	return Prefix + "Page"
+}
+
+// Description implements Step.
+func (s *Page) Description() string {
+	return "resolves to the nodes of the page of the given number of the current path, when split to pages of the given size. Page numbers start at 1."
+}
+
+// BuildIterator implements IteratorStep.
+func (s *Page) BuildIterator(qs graph.QuadStore) (query.Iterator, error) {
+	return NewValueIteratorFromPathStep(s, qs)
+}
+
+// BuildPath implements PathStep.
+func (s *Page) BuildPath(qs graph.QuadStore) (*path.Path, error) {
+	if s.Size <= 0 {
+		return nil, fmt.Errorf("Page expects size to be positive, got %d", s.Size)
+	}
+	if s.Number < 1 {
+		return nil, fmt.Errorf("Page expects number to be at least 1, got %d", s.Number)
+	}
+	fromPath, err := s.From.BuildPath(qs)
+	if err != nil {
+		return nil, err
+	}
+	return fromPath.Skip((s.Number - 1) * s.Size).Limit(s.Size), nil
+}
+
 var _ IteratorStep = (*PropertyNames)(nil)
 var _ PathStep = (*PropertyNames)(nil)
 
@@ -893,6 +1904,39 @@ func (s *PropertyNames) BuildPath(qs graph.QuadStore) (*path.Path, error) {
 	return fromPath.OutPredicates(), nil
 }
 
+var _ IteratorStep = (*PropertyNamesTo)(nil)
+var _ PathStep = (*PropertyNamesTo)(nil)
+
+// PropertyNamesTo corresponds to .propertyNamesTo().
+type PropertyNamesTo struct {
+	From PathStep     `json:"from"`
+	To   []quad.Value `json:"to"`
+}
+
+// Type implements Step.
+func (s *PropertyNamesTo) Type() quad.IRI {
+	return Prefix + "PropertyNamesTo"
+}
+
+// Description implements Step.
+func (s *PropertyNamesTo) Description() string {
+	return "gets the list of predicates that are pointing out from a node to any of the provided values."
+}
+
+// BuildIterator implements IteratorStep.
+func (s *PropertyNamesTo) BuildIterator(qs graph.QuadStore) (query.Iterator, error) {
+	return NewValueIteratorFromPathStep(s, qs)
+}
+
+// BuildPath implements PathStep.
+func (s *PropertyNamesTo) BuildPath(qs graph.QuadStore) (*path.Path, error) {
+	fromPath, err := s.From.BuildPath(qs)
+	if err != nil {
+		return nil, err
+	}
+	return fromPath.OutPredicatesTo(path.StartPath(qs, s.To...)), nil
+}
+
 var _ IteratorStep = (*Properties)(nil)
 var _ PathStep = (*Properties)(nil)
 
@@ -901,6 +1945,8 @@ type Properties struct {
 	From PathStep `json:"from"`
 	// TODO(iddan): Use PropertyPath
 	Names []quad.IRI `json:"names"`
+	// Aliases maps property IRIs to the tags their values are saved to.
+	Aliases map[string]string `json:"aliases,omitempty"`
 }
 
 // Type implements Step.
@@ -910,7 +1956,7 @@ func (s *Properties) Type() quad.IRI {
 
 // Description implements Step.
 func (s *Properties) Description() string {
-	return "adds tags for all properties of the current entity"
+	return "adds tags for all properties of the current entity. The tags are the property IRIs unless mapped to another name in aliases."
 }
 
 // BuildIterator implements IteratorStep.
@@ -926,119 +1972,236 @@ func (s *Properties) BuildPath(qs graph.QuadStore) (*path.Path, error) {
 		return nil, err
 	}
 	p := fromPath
-	if s.Names != nil {
-		for _, name := range s.Names {
-			tag := string(name)
-			p = p.Save(name, tag)
-		}
-	} else {
-		panic("Not implemented: should tag all properties")
+	if s.Names != nil {
+		for _, name := range s.Names {
+			tag := string(name)
+			if alias, ok := s.Aliases[tag]; ok {
+				tag = alias
+			}
+			p = p.Save(name, tag)
+		}
+	} else {
+		panic("Not implemented: should tag all properties")
+	}
+	return p, nil
+}
+
+var _ IteratorStep = (*ReversePropertyNamesAs)(nil)
+var _ PathStep = (*ReversePropertyNamesAs)(nil)
+
+// ReversePropertyNamesAs corresponds to .reversePropertyNamesAs().
+type ReversePropertyNamesAs struct {
+	From PathStep `json:"from"`
+	Tag  string   `json:"tag"`
+}
+
+// Type implements Step.
+func (s *ReversePropertyNamesAs) Type() quad.IRI {
+	return Prefix + "ReversePropertyNamesAs"
+}
+
+// Description implements Step.
+func (s *ReversePropertyNamesAs) Description() string {
+	return "tags the list of predicates that are pointing in to a node."
+}
+
+// BuildIterator implements IteratorStep.
+func (s *ReversePropertyNamesAs) BuildIterator(qs graph.QuadStore) (query.Iterator, error) {
+	return NewValueIteratorFromPathStep(s, qs)
+}
+
+// BuildPath implements PathStep.
+func (s *ReversePropertyNamesAs) BuildPath(qs graph.QuadStore) (*path.Path, error) {
+	fromPath, err := s.From.BuildPath(qs)
+	if err != nil {
+		return nil, err
+	}
+	return fromPath.SavePredicates(true, s.Tag), nil
+}
+
+var _ IteratorStep = (*PropertyNamesAs)(nil)
+var _ PathStep = (*PropertyNamesAs)(nil)
+
+// PropertyNamesAs corresponds to .propertyNamesAs().
+type PropertyNamesAs struct {
+	From PathStep `json:"from"`
+	Tag  string   `json:"tag"`
+}
+
+// Type implements Step.
+func (s *PropertyNamesAs) Type() quad.IRI {
+	return Prefix + "PropertyNamesAs"
+}
+
+// Description implements Step.
+func (s *PropertyNamesAs) Description() string {
+	return "tags the list of predicates that are pointing out from a node."
+}
+
+// BuildIterator implements IteratorStep.
+func (s *PropertyNamesAs) BuildIterator(qs graph.QuadStore) (query.Iterator, error) {
+	return NewValueIteratorFromPathStep(s, qs)
+}
+
+// BuildPath implements PathStep.
+func (s *PropertyNamesAs) BuildPath(qs graph.QuadStore) (*path.Path, error) {
+	fromPath, err := s.From.BuildPath(qs)
+	if err != nil {
+		return nil, err
+	}
+	return fromPath.SavePredicates(false, s.Tag), nil
+}
+
+var _ IteratorStep = (*ReverseProperties)(nil)
+var _ PathStep = (*ReverseProperties)(nil)
+
+// ReverseProperties corresponds to .reverseProperties().
+type ReverseProperties struct {
+	From PathStep `json:"from"`
+	// TODO(iddan): Use property path
+	Names []quad.IRI `json:"names"`
+}
+
+// Type implements Step.
+func (s *ReverseProperties) Type() quad.IRI {
+	return Prefix + "ReverseProperties"
+}
+
+// Description implements Step.
+func (s *ReverseProperties) Description() string {
+	return "gets all the properties the current entity / value is referenced at"
+}
+
+// BuildIterator implements IteratorStep.
+func (s *ReverseProperties) BuildIterator(qs graph.QuadStore) (query.Iterator, error) {
+	return NewValueIteratorFromPathStep(s, qs)
+}
+
+// BuildPath implements PathStep.
+func (s *ReverseProperties) BuildPath(qs graph.QuadStore) (*path.Path, error) {
+	fromPath, err := s.From.BuildPath(qs)
+	if err != nil {
+		return nil, err
+	}
+	p := fromPath
+	for _, name := range s.Names {
+		p = fromPath.SaveReverse(name, string(name))
 	}
 	return p, nil
 }
 
-var _ IteratorStep = (*ReversePropertyNamesAs)(nil)
-var _ PathStep = (*ReversePropertyNamesAs)(nil)
+var _ IteratorStep = (*Save)(nil)
+var _ PathStep = (*Save)(nil)
 
-// ReversePropertyNamesAs corresponds to .reversePropertyNamesAs().
-type ReversePropertyNamesAs struct {
-	From PathStep `json:"from"`
-	Tag  string   `json:"tag"`
+// Save corresponds to .save().
+type Save struct {
+	From     PathStep     `json:"from"`
+	Property PropertyPath `json:"property"`
+	Tag      string       `json:"tag"`
 }
 
 // Type implements Step.
-func (s *ReversePropertyNamesAs) Type() quad.IRI {
-	return Prefix + "ReversePropertyNamesAs"
+func (s *Save) Type() quad.IRI {
+	return Prefix + "Save"
 }
 
 // Description implements Step.
-func (s *ReversePropertyNamesAs) Description() string {
-	return "tags the list of predicates that are pointing in to a node."
+func (s *Save) Description() string {
+	return "assigns the values of the given property of the current entities to a given name without changing the current entities. Entities without the property are removed from the results."
 }
 
 // BuildIterator implements IteratorStep.
-func (s *ReversePropertyNamesAs) BuildIterator(qs graph.QuadStore) (query.Iterator, error) {
+func (s *Save) BuildIterator(qs graph.QuadStore) (query.Iterator, error) {
 	return NewValueIteratorFromPathStep(s, qs)
 }
 
 // BuildPath implements PathStep.
-func (s *ReversePropertyNamesAs) BuildPath(qs graph.QuadStore) (*path.Path, error) {
+func (s *Save) BuildPath(qs graph.QuadStore) (*path.Path, error) {
 	fromPath, err := s.From.BuildPath(qs)
 	if err != nil {
 		return nil, err
 	}
-	return fromPath.SavePredicates(true, s.Tag), nil
+	viaPath, err := s.Property.BuildPath(qs)
+	if err != nil {
+		return nil, err
+	}
+	return fromPath.Save(viaPath, s.Tag), nil
 }
 
-var _ IteratorStep = (*PropertyNamesAs)(nil)
-var _ PathStep = (*PropertyNamesAs)(nil)
+var _ IteratorStep = (*SaveReverse)(nil)
+var _ PathStep = (*SaveReverse)(nil)
 
-// PropertyNamesAs corresponds to .propertyNamesAs().
-type PropertyNamesAs struct {
-	From PathStep `json:"from"`
-	Tag  string   `json:"tag"`
+// SaveReverse corresponds to .saveR().
+type SaveReverse struct {
+	From     PathStep     `json:"from"`
+	Property PropertyPath `json:"property"`
+	Tag      string       `json:"tag"`
 }
 
 // Type implements Step.
-func (s *PropertyNamesAs) Type() quad.IRI {
-	return Prefix + "PropertyNamesAs"
+func (s *SaveReverse) Type() quad.IRI {
+	return Prefix + "SaveReverse"
 }
 
 // Description implements Step.
-func (s *PropertyNamesAs) Description() string {
-	return "tags the list of predicates that are pointing out from a node."
+func (s *SaveReverse) Description() string {
+	return "is the same as Save, but assigns the entities referencing the current entities / values with the given property."
 }
 
 // BuildIterator implements IteratorStep.
-func (s *PropertyNamesAs) BuildIterator(qs graph.QuadStore) (query.Iterator, error) {
+func (s *SaveReverse) BuildIterator(qs graph.QuadStore) (query.Iterator, error) {
 	return NewValueIteratorFromPathStep(s, qs)
 }
 
 // BuildPath implements PathStep.
-func (s *PropertyNamesAs) BuildPath(qs graph.QuadStore) (*path.Path, error) {
+func (s *SaveReverse) BuildPath(qs graph.QuadStore) (*path.Path, error) {
 	fromPath, err := s.From.BuildPath(qs)
 	if err != nil {
 		return nil, err
 	}
-	return fromPath.SavePredicates(false, s.Tag), nil
+	viaPath, err := s.Property.BuildPath(qs)
+	if err != nil {
+		return nil, err
+	}
+	return fromPath.SaveReverse(viaPath, s.Tag), nil
 }
 
-var _ IteratorStep = (*ReverseProperties)(nil)
-var _ PathStep = (*ReverseProperties)(nil)
+var _ IteratorStep = (*SaveOptional)(nil)
+var _ PathStep = (*SaveOptional)(nil)
 
-// ReverseProperties corresponds to .reverseProperties().
-type ReverseProperties struct {
-	From PathStep `json:"from"`
-	// TODO(iddan): Use property path
-	Names []quad.IRI `json:"names"`
+// SaveOptional corresponds to .saveOpt().
+type SaveOptional struct {
+	From     PathStep     `json:"from"`
+	Property PropertyPath `json:"property"`
+	Tag      string       `json:"tag"`
 }
 
 // Type implements Step.
-func (s *ReverseProperties) Type() quad.IRI {
-	return Prefix + "ReverseProperties"
+func (s *SaveOptional) Type() quad.IRI {
+	return Prefix + "SaveOptional"
 }
 
 // Description implements Step.
-func (s *ReverseProperties) Description() string {
-	return "gets all the properties the current entity / value is referenced at"
+func (s *SaveOptional) Description() string {
+	return "is the same as Save, but entities without the property are kept in the results without the name assigned."
 }
 
 // BuildIterator implements IteratorStep.
-func (s *ReverseProperties) BuildIterator(qs graph.QuadStore) (query.Iterator, error) {
+func (s *SaveOptional) BuildIterator(qs graph.QuadStore) (query.Iterator, error) {
 	return NewValueIteratorFromPathStep(s, qs)
 }
 
 // BuildPath implements PathStep.
-func (s *ReverseProperties) BuildPath(qs graph.QuadStore) (*path.Path, error) {
+func (s *SaveOptional) BuildPath(qs graph.QuadStore) (*path.Path, error) {
 	fromPath, err := s.From.BuildPath(qs)
 	if err != nil {
 		return nil, err
 	}
-	p := fromPath
-	for _, name := range s.Names {
-		p = fromPath.SaveReverse(name, string(name))
+	viaPath, err := s.Property.BuildPath(qs)
+	if err != nil {
+		return nil, err
 	}
-	return p, nil
+	return fromPath.SaveOptional(viaPath, s.Tag), nil
 }
 
 var _ IteratorStep = (*Skip)(nil)
@@ -1152,7 +2315,9 @@ var _ PathStep = (*Order)(nil)
 
 // Order corresponds to .order().
 type Order struct {
-	From PathStep `json:"from"`
+	From       PathStep `json:"from"`
+	Descending bool     `json:"descending,omitempty"`
+	Collation  string   `json:"collation,omitempty"`
 }
 
 // Type implements Step.
@@ -1162,7 +2327,7 @@ func (s *Order) Type() quad.IRI {
 
 // Description implements Step.
 func (s *Order) Description() string {
-	return "sorts the results in ascending order according to the current entity / value"
+	return "sorts the results in ascending order according to the current entity / value. If descending is set to true sorts the results in descending order. Collation selects the comparator: binary (default), case-insensitive or numeric."
 }
 
 // BuildIterator implements IteratorStep.
@@ -1176,6 +2341,16 @@ func (s *Order) BuildPath(qs graph.QuadStore) (*path.Path, error) {
 	if err != nil {
 		return nil, err
 	}
+	less, err := collation(s.Collation)
+	if err != nil {
+		return nil, err
+	}
+	if less != nil {
+		return fromPath.OrderWith(less, s.Descending), nil
+	}
+	if s.Descending {
+		return fromPath.OrderDescending(), nil
+	}
 	return fromPath.Order(), nil
 }
 
@@ -1287,7 +2462,7 @@ func (s *LessThan) BuildPath(qs graph.QuadStore) (*path.Path, error) {
 	if err != nil {
 		return nil, err
 	}
-	return fromPath.Filter(iterator.CompareLT, s.Value), nil
+	return comparisonPath(fromPath, comparisonFilter{op: iterator.CompareLT, value: s.Value})
 }
 
 var _ IteratorStep = (*LessThanEquals)(nil)
@@ -1320,7 +2495,7 @@ func (s *LessThanEquals) BuildPath(qs graph.QuadStore) (*path.Path, error) {
 	if err != nil {
 		return nil, err
 	}
-	return fromPath.Filter(iterator.CompareLTE, s.Value), nil
+	return comparisonPath(fromPath, comparisonFilter{op: iterator.CompareLTE, value: s.Value})
 }
 
 var _ IteratorStep = (*GreaterThan)(nil)
@@ -1353,7 +2528,7 @@ func (s *GreaterThan) BuildPath(qs graph.QuadStore) (*path.Path, error) {
 	if err != nil {
 		return nil, err
 	}
-	return fromPath.Filter(iterator.CompareGT, s.Value), nil
+	return comparisonPath(fromPath, comparisonFilter{op: iterator.CompareGT, value: s.Value})
 }
 
 var _ IteratorStep = (*GreaterThanEquals)(nil)
@@ -1386,5 +2561,312 @@ func (s *GreaterThanEquals) BuildPath(qs graph.QuadStore) (*path.Path, error) {
 	if err != nil {
 		return nil, err
 	}
-	return fromPath.Filter(iterator.CompareGTE, s.Value), nil
+	return comparisonPath(fromPath, comparisonFilter{op: iterator.CompareGTE, value: s.Value})
+}
+
+var _ IteratorStep = (*Equals)(nil)
+var _ PathStep = (*Equals)(nil)
+
+// Equals corresponds to eq().
+type Equals struct {
+	From      PathStep   `json:"from"`
+	Value     quad.Value `json:"value"`
+	Normalize bool       `json:"normalize,omitempty"`
+}
+
+// Type implements Step.
+func (s *Equals) Type() quad.IRI {
+	return Prefix + "Equals"
+}
+
+// Description implements Step.
+func (s *Equals) Description() string {
+	return "Equals filters out values that are not equal to given value. If normalize is set IRIs are compared after expanding registered namespace prefixes."
+}
+
+// BuildIterator implements Step.
+func (s *Equals) BuildIterator(qs graph.QuadStore) (query.Iterator, error) {
+	return NewValueIteratorFromPathStep(s, qs)
+}
+
+// BuildPath implements Step.
+func (s *Equals) BuildPath(qs graph.QuadStore) (*path.Path, error) {
+	fromPath, err := s.From.BuildPath(qs)
+	if err != nil {
+		return nil, err
+	}
+	if s.Normalize {
+		return fromPath.Filters(normalizedFilter([]quad.Value{s.Value})), nil
+	}
+	return fromPath.Is(s.Value), nil
+}
+
+var _ IteratorStep = (*NotEquals)(nil)
+var _ PathStep = (*NotEquals)(nil)
+
+// NotEquals corresponds to neq().
+type NotEquals struct {
+	From  PathStep   `json:"from"`
+	Value quad.Value `json:"value"`
+}
+
+// Type implements Step.
+func (s *NotEquals) Type() quad.IRI {
+	return Prefix + "NotEquals"
+}
+
+// Description implements Step.
+func (s *NotEquals) Description() string {
+	return "Not equals filters out values that are equal to given value"
+}
+
+// BuildIterator implements Step.
+func (s *NotEquals) BuildIterator(qs graph.QuadStore) (query.Iterator, error) {
+	return NewValueIteratorFromPathStep(s, qs)
+}
+
+// BuildPath implements Step.
+func (s *NotEquals) BuildPath(qs graph.QuadStore) (*path.Path, error) {
+	fromPath, err := s.From.BuildPath(qs)
+	if err != nil {
+		return nil, err
+	}
+	return fromPath.Except(path.StartPath(qs, s.Value)), nil
+}
+
+var _ IteratorStep = (*Between)(nil)
+var _ PathStep = (*Between)(nil)
+
+// Between corresponds to between().
+type Between struct {
+	From PathStep   `json:"from"`
+	Min  quad.Value `json:"min"`
+	Max  quad.Value `json:"max"`
+}
+
+// Type implements Step.
+func (s *Between) Type() quad.IRI {
+	return Prefix + "Between"
+}
+
+// Description implements Step.
+func (s *Between) Description() string {
+	return "Between filters out values that are not greater than or equal min and less than or equal max"
+}
+
+// BuildIterator implements Step.
+func (s *Between) BuildIterator(qs graph.QuadStore) (query.Iterator, error) {
+	return NewValueIteratorFromPathStep(s, qs)
+}
+
+// BuildPath implements Step.
+func (s *Between) BuildPath(qs graph.QuadStore) (*path.Path, error) {
+	fromPath, err := s.From.BuildPath(qs)
+	if err != nil {
+		return nil, err
+	}
+	return comparisonPath(fromPath,
+		comparisonFilter{op: iterator.CompareGTE, value: s.Min},
+		comparisonFilter{op: iterator.CompareLTE, value: s.Max},
+	)
+}
+
+var _ IteratorStep = (*IsIRI)(nil)
+var _ PathStep = (*IsIRI)(nil)
+
+// IsIRI corresponds to isIRI().
+type IsIRI struct {
+	From PathStep `json:"from"`
+}
+
+// Type implements Step.
+func (s *IsIRI) Type() quad.IRI {
+	return Prefix + "IsIRI"
+}
+
+// Description implements Step.
+func (s *IsIRI) Description() string {
+	return "Is IRI filters out values that are not IRIs"
+}
+
+// BuildIterator implements Step.
+func (s *IsIRI) BuildIterator(qs graph.QuadStore) (query.Iterator, error) {
+	return NewValueIteratorFromPathStep(s, qs)
+}
+
+// BuildPath implements Step.
+func (s *IsIRI) BuildPath(qs graph.QuadStore) (*path.Path, error) {
+	fromPath, err := s.From.BuildPath(qs)
+	if err != nil {
+		return nil, err
+	}
+	return fromPath.Filters(valueFilter(isIRI)), nil
+}
+
+var _ IteratorStep = (*IsLiteral)(nil)
+var _ PathStep = (*IsLiteral)(nil)
+
+// IsLiteral corresponds to isLiteral().
+type IsLiteral struct {
+	From PathStep `json:"from"`
+}
+
+// Type implements Step.
+func (s *IsLiteral) Type() quad.IRI {
+	return Prefix + "IsLiteral"
+}
+
+// Description implements Step.
+func (s *IsLiteral) Description() string {
+	return "Is literal filters out values that are not literals"
+}
+
+// BuildIterator implements Step.
+func (s *IsLiteral) BuildIterator(qs graph.QuadStore) (query.Iterator, error) {
+	return NewValueIteratorFromPathStep(s, qs)
+}
+
+// BuildPath implements Step.
+func (s *IsLiteral) BuildPath(qs graph.QuadStore) (*path.Path, error) {
+	fromPath, err := s.From.BuildPath(qs)
+	if err != nil {
+		return nil, err
+	}
+	return fromPath.Filters(valueFilter(isLiteral)), nil
+}
+
+var _ IteratorStep = (*IsBlankNode)(nil)
+var _ PathStep = (*IsBlankNode)(nil)
+
+// IsBlankNode corresponds to isBlankNode().
+type IsBlankNode struct {
+	From PathStep `json:"from"`
+}
+
+// Type implements Step.
+func (s *IsBlankNode) Type() quad.IRI {
+	return Prefix + "IsBlankNode"
+}
+
+// Description implements Step.
+func (s *IsBlankNode) Description() string {
+	return "Is blank node filters out values that are not blank nodes"
+}
+
+// BuildIterator implements Step.
+func (s *IsBlankNode) BuildIterator(qs graph.QuadStore) (query.Iterator, error) {
+	return NewValueIteratorFromPathStep(s, qs)
+}
+
+// BuildPath implements Step.
+func (s *IsBlankNode) BuildPath(qs graph.QuadStore) (*path.Path, error) {
+	fromPath, err := s.From.BuildPath(qs)
+	if err != nil {
+		return nil, err
+	}
+	return fromPath.Filters(valueFilter(isBlankNode)), nil
+}
+
+var _ IteratorStep = (*HasDatatype)(nil)
+var _ PathStep = (*HasDatatype)(nil)
+
+// HasDatatype corresponds to hasDatatype().
+type HasDatatype struct {
+	From     PathStep `json:"from"`
+	Datatype quad.IRI `json:"datatype"`
+}
+
+// Type implements Step.
+func (s *HasDatatype) Type() quad.IRI {
+	return Prefix + "HasDatatype"
+}
+
+// Description implements Step.
+func (s *HasDatatype) Description() string {
+	return "Has datatype filters out values that are not typed literals of given datatype"
+}
+
+// BuildIterator implements Step.
+func (s *HasDatatype) BuildIterator(qs graph.QuadStore) (query.Iterator, error) {
+	return NewValueIteratorFromPathStep(s, qs)
+}
+
+// BuildPath implements Step.
+func (s *HasDatatype) BuildPath(qs graph.QuadStore) (*path.Path, error) {
+	fromPath, err := s.From.BuildPath(qs)
+	if err != nil {
+		return nil, err
+	}
+	return fromPath.Filters(datatypeFilter(s.Datatype)), nil
+}
+
+// datatypeFilter returns a value filter keeping typed literals of the given datatype.
+func datatypeFilter(datatype quad.IRI) valueFilter {
+	full := datatype.Full()
+	return func(v quad.Value) bool {
+		typ, ok := datatypeOf(v)
+		return ok && typ.Full() == full
+	}
+}
+
+var _ IteratorStep = (*HasLanguage)(nil)
+var _ PathStep = (*HasLanguage)(nil)
+
+// HasLanguage corresponds to hasLanguage().
+type HasLanguage struct {
+	From     PathStep `json:"from"`
+	Language string   `json:"language"`
+}
+
+// Type implements Step.
+func (s *HasLanguage) Type() quad.IRI {
+	return Prefix + "HasLanguage"
+}
+
+// Description implements Step.
+func (s *HasLanguage) Description() string {
+	return "Has language filters out values that are not strings tagged with given language. Languages are matched case-insensitively."
+}
+
+// BuildIterator implements Step.
+func (s *HasLanguage) BuildIterator(qs graph.QuadStore) (query.Iterator, error) {
+	return NewValueIteratorFromPathStep(s, qs)
+}
+
+// BuildPath implements Step.
+func (s *HasLanguage) BuildPath(qs graph.QuadStore) (*path.Path, error) {
+	fromPath, err := s.From.BuildPath(qs)
+	if err != nil {
+		return nil, err
+	}
+	return fromPath.Filters(valueFilter(func(v quad.Value) bool {
+		ls, ok := v.(quad.LangString)
+		// BCP 47 language tags are case-insensitive
+		return ok && strings.EqualFold(ls.Lang, s.Language)
+	})), nil
+}
+
+func isIRI(v quad.Value) bool {
+	_, ok := v.(quad.IRI)
+	return ok
+}
+
+func isBlankNode(v quad.Value) bool {
+	_, ok := v.(quad.BNode)
+	return ok
+}
+
+func isLiteral(v quad.Value) bool {
+	return v != nil && !isIRI(v) && !isBlankNode(v)
+}
+
+// datatypeOf returns the datatype of a typed literal.
+func datatypeOf(v quad.Value) (quad.IRI, bool) {
+	switch v := v.(type) {
+	case quad.TypedString:
+		return v.Type, true
+	case quad.TypedStringer:
+		return v.TypedString().Type, true
+	}
+	return "", false
 }
@@ -1,8 +1,14 @@
 package linkedql
 
 import (
+	"fmt"
+	"reflect"
+	"time"
+
 	"github.com/cayleygraph/cayley/graph"
 	"github.com/cayleygraph/cayley/query"
+	"github.com/cayleygraph/cayley/query/path"
+	"github.com/cayleygraph/cayley/query/shape"
 	"github.com/cayleygraph/quad"
 )
 
@@ -11,14 +17,39 @@ func init() {
 	Register(&SelectFirst{})
 	Register(&Value{})
 	Register(&Documents{})
+	Register(&Average{})
+	Register(&Exists{})
+	Register(&GroupCount{})
+	Register(&OrderBy{})
+	Register(&UniqueBy{})
+	Register(&LimitPerGroup{})
+	Register(&CountValues{})
+	Register(&Paginate{})
+	Register(&WithTotal{})
+	Register(&ShortestPath{})
+	Register(&GroupBy{})
+	Register(&Tail{})
+	Register(&Sample{})
+	Register(&Frame{})
+	Register(&PropertyValues{})
+	Register(&Describe{})
+	Register(&Adjacency{})
+	Register(&Centrality{})
+	Register(&Quads{})
+	Register(&Construct{})
+	Register(&ValueType{})
+	Register(&StringJoin{})
+	Register(&DetectCycles{})
+	Register(&Trace{})
 }
 
 var _ IteratorStep = (*Select)(nil)
 
 // Select corresponds to .select().
 type Select struct {
-	Tags []string `json:"tags"`
-	From PathStep `json:"from"`
+	Tags        []string `json:"tags"`
+	From        PathStep `json:"from"`
+	FillMissing bool     `json:"fillMissing,omitempty"`
 }
 
 // Type implements Step.
@@ -28,7 +59,7 @@ func (s *Select) Type() quad.IRI {
 
 // Description implements Step.
 func (s *Select) Description() string {
-	return "Select returns flat records of tags matched in the query"
+	return "Select returns flat records of tags matched in the query. If fillMissing is set, selected tags without a value are returned as null"
 }
 
 // BuildIterator implements IteratorStep
@@ -37,7 +68,7 @@ func (s *Select) BuildIterator(qs graph.QuadStore) (query.Iterator, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &TagsIterator{valueIt: valueIt, selected: s.Tags}, nil
+	return &TagsIterator{valueIt: valueIt, selected: s.Tags, FillMissing: s.FillMissing}, nil
 }
 
 var _ IteratorStep = (*SelectFirst)(nil)
@@ -72,7 +103,7 @@ func (s *SelectFirst) BuildIterator(qs graph.QuadStore) (query.Iterator, error)
 	if err != nil {
 		return nil, err
 	}
-	return &TagsIterator{it, s.Tags}, nil
+	return &TagsIterator{valueIt: it, selected: s.Tags}, nil
 }
 
 var _ IteratorStep = (*Value)(nil)
@@ -101,9 +132,17 @@ var _ IteratorStep = (*Documents)(nil)
 
 // Documents corresponds to .documents().
 type Documents struct {
-	From PathStep `json:"from"`
+	From         PathStep          `json:"from"`
+	Context      map[string]string `json:"context,omitempty"`
+	ReverseNames []quad.IRI        `json:"reverseNames,omitempty"`
+	Skolemize    bool              `json:"skolemize,omitempty"`
+	BaseIRI      string            `json:"baseIRI,omitempty"`
+	BatchSize    int               `json:"batchSize,omitempty"`
 }
 
+// reverseTagPrefix prefixes the tags Documents uses internally to collect inbound properties.
+const reverseTagPrefix = Prefix + "reverse:"
+
 // Type implements Step.
 func (s *Documents) Type() quad.IRI {
 	return Prefix + "Documents"
@@ -111,18 +150,866 @@ func (s *Documents) Type() quad.IRI {
 
 // Description implements Step.
 func (s *Documents) Description() string {
-	return "Documents return documents of the tags matched in the query associated with their entity"
+	return "Documents return documents of the tags matched in the query associated with their entity. If context is set, it is used as a JSON-LD context mapping terms to IRIs to compact the keys and identifiers of the documents. Entities linking to the entity with any of the properties named in reverseNames are listed under @reverse. If skolemize is set, blank nodes are replaced with baseIRI/.well-known/genid/ IRIs. If from is Properties, all the values of its properties are listed. If batchSize is set, the properties of up to batchSize entities and the entities linking to them are read at once instead of one entity at a time."
 }
 
 // BuildIterator implements IteratorStep
 func (s *Documents) BuildIterator(qs graph.QuadStore) (query.Iterator, error) {
-	p, err := s.From.BuildPath(qs)
+	outbound := make(map[quad.Value]string)
+	var (
+		p   *path.Path
+		err error
+	)
+	if props, ok := s.From.(*Properties); ok && props.Names != nil {
+		// the properties are read from the quad store, only entities having all of them are kept
+		p, err = props.From.BuildPath(qs)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range props.Names {
+			tag := string(name)
+			if alias, ok := props.Aliases[tag]; ok {
+				tag = alias
+			}
+			p = p.Has(name)
+			outbound[name] = tag
+		}
+	} else if p, err = s.From.BuildPath(qs); err != nil {
+		return nil, err
+	}
+	inbound := make(map[quad.Value]string, len(s.ReverseNames))
+	reverse := make(map[string]string, len(s.ReverseNames))
+	for _, name := range s.ReverseNames {
+		tag := reverseTagPrefix + string(name)
+		inbound[name] = tag
+		reverse[tag] = string(name)
+	}
+	docIt := NewCompactDocumentIterator(NewValueIterator(p, qs), s.Context)
+	docIt.qs = qs
+	docIt.outbound = outbound
+	docIt.inbound = inbound
+	docIt.reverse = reverse
+	docIt.BatchSize = s.BatchSize
+	if s.Skolemize {
+		docIt.Skolemize(s.BaseIRI)
+	}
+	return docIt, nil
+}
+
+var _ IteratorStep = (*Frame)(nil)
+
+// Frame corresponds to .frame().
+type Frame struct {
+	From  PathStep               `json:"from"`
+	Frame map[string]interface{} `json:"frame"`
+}
+
+// Type implements Step.
+func (s *Frame) Type() quad.IRI {
+	return Prefix + "Frame"
+}
+
+// Description implements Step.
+func (s *Frame) Description() string {
+	return "Frame returns the documents Documents returns shaped by the given JSON-LD frame. Entities referenced by properties set to an object in the frame are embedded, framed by that object. If @explicit is set to true only the properties of the frame are kept."
+}
+
+// BuildIterator implements IteratorStep
+func (s *Frame) BuildIterator(qs graph.QuadStore) (query.Iterator, error) {
+	valueIt, err := NewValueIteratorFromPathStep(s.From, qs)
+	if err != nil {
+		return nil, err
+	}
+	return NewFrameIterator(qs, NewDocumentIterator(valueIt), s.Frame), nil
+}
+
+var _ IteratorStep = (*PropertyValues)(nil)
+
+// PropertyValues corresponds to .propertyValues().
+type PropertyValues struct {
+	From PathStep `json:"from"`
+}
+
+// Type implements Step.
+func (s *PropertyValues) Type() quad.IRI {
+	return Prefix + "PropertyValues"
+}
+
+// Description implements Step.
+func (s *PropertyValues) Description() string {
+	return "PropertyValues returns for each entity matched in the query a document with all its properties and their values"
+}
+
+// BuildIterator implements IteratorStep
+func (s *PropertyValues) BuildIterator(qs graph.QuadStore) (query.Iterator, error) {
+	valueIt, err := NewValueIteratorFromPathStep(s.From, qs)
+	if err != nil {
+		return nil, err
+	}
+	return NewPropertyValuesIterator(qs, valueIt), nil
+}
+
+var _ IteratorStep = (*Describe)(nil)
+
+// Describe corresponds to .describe().
+type Describe struct {
+	From PathStep `json:"from"`
+}
+
+// Type implements Step.
+func (s *Describe) Type() quad.IRI {
+	return Prefix + "Describe"
+}
+
+// Description implements Step.
+func (s *Describe) Description() string {
+	return "Describe returns for each entity matched in the query a document with all its properties and their values, and under @reverse all the properties linking other entities to it"
+}
+
+// BuildIterator implements IteratorStep
+func (s *Describe) BuildIterator(qs graph.QuadStore) (query.Iterator, error) {
+	valueIt, err := NewValueIteratorFromPathStep(s.From, qs)
+	if err != nil {
+		return nil, err
+	}
+	return NewDescribeIterator(qs, valueIt), nil
+}
+
+var _ IteratorStep = (*Adjacency)(nil)
+
+// Adjacency corresponds to .adjacency().
+type Adjacency struct {
+	From PathStep `json:"from"`
+}
+
+// Type implements Step.
+func (s *Adjacency) Type() quad.IRI {
+	return Prefix + "Adjacency"
+}
+
+// Description implements Step.
+func (s *Adjacency) Description() string {
+	return "Adjacency returns for each entity matched in the query a document with its outgoing edges grouped by property under out and its incoming edges grouped by property under in"
+}
+
+// BuildIterator implements IteratorStep
+func (s *Adjacency) BuildIterator(qs graph.QuadStore) (query.Iterator, error) {
+	valueIt, err := NewValueIteratorFromPathStep(s.From, qs)
+	if err != nil {
+		return nil, err
+	}
+	return NewAdjacencyIterator(qs, valueIt), nil
+}
+
+var _ IteratorStep = (*Centrality)(nil)
+
+// Centrality corresponds to .centrality().
+type Centrality struct {
+	From PathStep `json:"from"`
+	// Metric is the centrality measure to compute. Only "degree" is supported.
+	Metric string       `json:"metric"`
+	Via    PropertyPath `json:"via"`
+}
+
+// Type implements Step.
+func (s *Centrality) Type() quad.IRI {
+	return Prefix + "Centrality"
+}
+
+// Description implements Step.
+func (s *Centrality) Description() string {
+	return "Centrality returns for each entity matched in the query a document with its centrality score under score. With the degree metric the score is the number of edges of the via property from or to the entity"
+}
+
+// BuildIterator implements IteratorStep
+func (s *Centrality) BuildIterator(qs graph.QuadStore) (query.Iterator, error) {
+	valueIt, err := NewValueIteratorFromPathStep(s.From, qs)
+	if err != nil {
+		return nil, err
+	}
+	viaPath, err := s.Via.BuildPath(qs)
+	if err != nil {
+		return nil, err
+	}
+	switch s.Metric {
+	case "degree":
+		return NewDegreeCentralityIterator(qs, valueIt, viaPath), nil
+	}
+	return nil, fmt.Errorf("unknown centrality metric: %q", s.Metric)
+}
+
+var _ IteratorStep = (*Average)(nil)
+
+// Average corresponds to .average().
+type Average struct {
+	From PathStep `json:"from"`
+}
+
+// Type implements Step.
+func (s *Average) Type() quad.IRI {
+	return Prefix + "Average"
+}
+
+// Description implements Step.
+func (s *Average) Description() string {
+	return "Average returns the average of the numeric values matched in the query. Non numeric values are ignored."
+}
+
+// BuildIterator implements IteratorStep
+func (s *Average) BuildIterator(qs graph.QuadStore) (query.Iterator, error) {
+	valueIt, err := NewValueIteratorFromPathStep(s.From, qs)
+	if err != nil {
+		return nil, err
+	}
+	return NewAverageIterator(valueIt), nil
+}
+
+var _ IteratorStep = (*Exists)(nil)
+
+// Exists corresponds to .exists().
+type Exists struct {
+	From PathStep `json:"from"`
+}
+
+// Type implements Step.
+func (s *Exists) Type() quad.IRI {
+	return Prefix + "Exists"
+}
+
+// Description implements Step.
+func (s *Exists) Description() string {
+	return "Exists returns a single boolean which is true if the query matches any value and false otherwise"
+}
+
+// BuildIterator implements IteratorStep
+func (s *Exists) BuildIterator(qs graph.QuadStore) (query.Iterator, error) {
+	valueIt, err := singleValueIteratorFromPathStep(s.From, qs)
+	if err != nil {
+		return nil, err
+	}
+	return NewExistsIterator(valueIt), nil
+}
+
+var _ IteratorStep = (*StringJoin)(nil)
+
+// StringJoin corresponds to .stringJoin().
+type StringJoin struct {
+	From      PathStep `json:"from"`
+	Separator string   `json:"separator,omitempty"`
+}
+
+// Type implements Step.
+func (s *StringJoin) Type() quad.IRI {
+	return Prefix + "StringJoin"
+}
+
+// Description implements Step.
+func (s *StringJoin) Description() string {
+	return "StringJoin returns a single string of the values matched in the query joined with the separator. Values which are not strings are joined in their lexical form."
+}
+
+// BuildIterator implements IteratorStep
+func (s *StringJoin) BuildIterator(qs graph.QuadStore) (query.Iterator, error) {
+	valueIt, err := NewValueIteratorFromPathStep(s.From, qs)
+	if err != nil {
+		return nil, err
+	}
+	return NewStringJoinIterator(valueIt, s.Separator), nil
+}
+
+var _ IteratorStep = (*GroupCount)(nil)
+
+// groupCountTag is the tag GroupCount uses internally to collect the group key.
+const groupCountTag = Prefix + "groupKey"
+
+// GroupCount corresponds to .groupCount().
+type GroupCount struct {
+	From     PathStep     `json:"from"`
+	Property PropertyPath `json:"property"`
+}
+
+// Type implements Step.
+func (s *GroupCount) Type() quad.IRI {
+	return Prefix + "GroupCount"
+}
+
+// Description implements Step.
+func (s *GroupCount) Description() string {
+	return "GroupCount returns for each distinct value of the given property a document with the value and the number of results matched in the query having it"
+}
+
+// BuildIterator implements IteratorStep
+func (s *GroupCount) BuildIterator(qs graph.QuadStore) (query.Iterator, error) {
+	fromPath, err := s.From.BuildPath(qs)
+	if err != nil {
+		return nil, err
+	}
+	propertyPath, err := s.Property.BuildPath(qs)
+	if err != nil {
+		return nil, err
+	}
+	valueIt := NewValueIterator(fromPath.Save(propertyPath, groupCountTag), qs)
+	tagsIt := &TagsIterator{valueIt: valueIt, selected: []string{groupCountTag}}
+	return NewGroupCountIterator(tagsIt, groupCountTag), nil
+}
+
+var _ IteratorStep = (*GroupBy)(nil)
+
+// groupByTag is the tag GroupBy uses internally to collect the group key.
+const groupByTag = Prefix + "groupByKey"
+
+// GroupBy corresponds to .groupBy().
+type GroupBy struct {
+	From PathStep     `json:"from"`
+	Key  PropertyPath `json:"key"`
+}
+
+// Type implements Step.
+func (s *GroupBy) Type() quad.IRI {
+	return Prefix + "GroupBy"
+}
+
+// Description implements Step.
+func (s *GroupBy) Description() string {
+	return "GroupBy returns for each distinct value of the given key property a document with the value and the documents of the results matched in the query having it as members"
+}
+
+// BuildIterator implements IteratorStep
+func (s *GroupBy) BuildIterator(qs graph.QuadStore) (query.Iterator, error) {
+	fromPath, err := s.From.BuildPath(qs)
+	if err != nil {
+		return nil, err
+	}
+	keyPath, err := s.Key.BuildPath(qs)
+	if err != nil {
+		return nil, err
+	}
+	valueIt := NewValueIterator(fromPath.Save(keyPath, groupByTag), qs)
+	tagsIt := &TagsIterator{valueIt: valueIt, selected: nil}
+	return NewGroupByIterator(tagsIt, groupByTag), nil
+}
+
+var _ IteratorStep = (*OrderBy)(nil)
+
+// orderByTag is the tag OrderBy uses internally to collect the sort key.
+const orderByTag = Prefix + "orderKey"
+
+// OrderBy corresponds to .orderBy().
+type OrderBy struct {
+	From       PathStep     `json:"from"`
+	Property   PropertyPath `json:"property"`
+	Descending bool         `json:"descending,omitempty"`
+}
+
+// Type implements Step.
+func (s *OrderBy) Type() quad.IRI {
+	return Prefix + "OrderBy"
+}
+
+// Description implements Step.
+func (s *OrderBy) Description() string {
+	return "OrderBy sorts the results in ascending order according to the value of the given property. If descending is set to true sorts the results in descending order. Results missing the property are sorted last."
+}
+
+// BuildIterator implements IteratorStep
+func (s *OrderBy) BuildIterator(qs graph.QuadStore) (query.Iterator, error) {
+	fromPath, err := s.From.BuildPath(qs)
+	if err != nil {
+		return nil, err
+	}
+	propertyPath, err := s.Property.BuildPath(qs)
+	if err != nil {
+		return nil, err
+	}
+	valueIt := NewValueIterator(fromPath.SaveOptional(propertyPath, orderByTag), qs)
+	tagsIt := &TagsIterator{valueIt: valueIt, selected: []string{orderByTag}}
+	return NewOrderByIterator(tagsIt, orderByTag, s.Descending), nil
+}
+
+var _ IteratorStep = (*UniqueBy)(nil)
+
+// uniqueByTag is the tag UniqueBy uses internally to collect the deduplication key.
+const uniqueByTag = Prefix + "uniqueKey"
+
+// UniqueBy corresponds to .uniqueBy().
+type UniqueBy struct {
+	From     PathStep     `json:"from"`
+	Property PropertyPath `json:"property"`
+}
+
+// Type implements Step.
+func (s *UniqueBy) Type() quad.IRI {
+	return Prefix + "UniqueBy"
+}
+
+// Description implements Step.
+func (s *UniqueBy) Description() string {
+	return "UniqueBy removes values sharing the value of the given property with a former value. Values missing the property are kept."
+}
+
+// BuildIterator implements IteratorStep
+func (s *UniqueBy) BuildIterator(qs graph.QuadStore) (query.Iterator, error) {
+	fromPath, err := s.From.BuildPath(qs)
 	if err != nil {
 		return nil, err
 	}
-	it, err := NewValueIterator(p, qs), nil
+	propertyPath, err := s.Property.BuildPath(qs)
 	if err != nil {
 		return nil, err
 	}
-	return NewDocumentIterator(it), nil
+	valueIt := NewValueIterator(fromPath.SaveOptional(propertyPath, uniqueByTag), qs)
+	tagsIt := &TagsIterator{valueIt: valueIt, selected: []string{uniqueByTag}}
+	return NewUniqueByIterator(tagsIt, uniqueByTag), nil
+}
+
+var _ IteratorStep = (*LimitPerGroup)(nil)
+
+// limitPerGroupTag is the tag LimitPerGroup uses internally to collect the group key.
+const limitPerGroupTag = Prefix + "groupKey"
+
+// LimitPerGroup corresponds to .limitPerGroup().
+type LimitPerGroup struct {
+	From  PathStep     `json:"from"`
+	Key   PropertyPath `json:"key"`
+	Count int          `json:"count"`
+}
+
+// Type implements Step.
+func (s *LimitPerGroup) Type() quad.IRI {
+	return Prefix + "LimitPerGroup"
+}
+
+// Description implements Step.
+func (s *LimitPerGroup) Description() string {
+	return "LimitPerGroup keeps up to count values for each distinct value of the given key property. Values missing the property are kept."
+}
+
+// BuildIterator implements IteratorStep
+func (s *LimitPerGroup) BuildIterator(qs graph.QuadStore) (query.Iterator, error) {
+	fromPath, err := s.From.BuildPath(qs)
+	if err != nil {
+		return nil, err
+	}
+	keyPath, err := s.Key.BuildPath(qs)
+	if err != nil {
+		return nil, err
+	}
+	valueIt := NewValueIterator(fromPath.SaveOptional(keyPath, limitPerGroupTag), qs)
+	tagsIt := &TagsIterator{valueIt: valueIt, selected: []string{limitPerGroupTag}}
+	return NewLimitPerGroupIterator(tagsIt, limitPerGroupTag, s.Count), nil
+}
+
+var _ IteratorStep = (*CountValues)(nil)
+
+// CountValues corresponds to .countValues().
+type CountValues struct {
+	From PathStep `json:"from"`
+}
+
+// Type implements Step.
+func (s *CountValues) Type() quad.IRI {
+	return Prefix + "CountValues"
+}
+
+// Description implements Step.
+func (s *CountValues) Description() string {
+	return "CountValues returns for each distinct value matched in the query a document with the value and the number of times it was matched"
+}
+
+// BuildIterator implements IteratorStep
+func (s *CountValues) BuildIterator(qs graph.QuadStore) (query.Iterator, error) {
+	valueIt, err := NewValueIteratorFromPathStep(s.From, qs)
+	if err != nil {
+		return nil, err
+	}
+	return NewCountValuesIterator(valueIt), nil
+}
+
+var _ IteratorStep = (*Paginate)(nil)
+
+// Paginate corresponds to .paginate().
+type Paginate struct {
+	From     PathStep `json:"from"`
+	PageSize int      `json:"pageSize"`
+	Cursor   string   `json:"cursor,omitempty"`
+}
+
+// Type implements Step.
+func (s *Paginate) Type() quad.IRI {
+	return Prefix + "Paginate"
+}
+
+// Description implements Step.
+func (s *Paginate) Description() string {
+	return "Paginate returns a single document with a page of up to pageSize distinct values matched in the query as results. Values are ordered so pages are stable. If more values follow, the document has a nextCursor to be passed as cursor to get the next page."
+}
+
+// BuildIterator implements IteratorStep
+func (s *Paginate) BuildIterator(qs graph.QuadStore) (query.Iterator, error) {
+	valueIt, err := NewValueIteratorFromPathStep(s.From, qs)
+	if err != nil {
+		return nil, err
+	}
+	return NewPaginateIterator(valueIt, s.PageSize, s.Cursor)
+}
+
+var _ IteratorStep = (*WithTotal)(nil)
+
+// WithTotal corresponds to .withTotal().
+type WithTotal struct {
+	From IteratorStep `json:"from"`
+}
+
+// Type implements Step.
+func (s *WithTotal) Type() quad.IRI {
+	return Prefix + "WithTotal"
+}
+
+// Description implements Step.
+func (s *WithTotal) Description() string {
+	return "WithTotal returns a single document with the values matched in the query as items and the number of values matched without the trailing limit, skip and page steps as total. From may also be a Paginate step, in which case the items are its page, the total is the number of distinct values and the cursor of the next page is set as nextCursor."
+}
+
+// BuildIterator implements IteratorStep
+func (s *WithTotal) BuildIterator(qs graph.QuadStore) (query.Iterator, error) {
+	if paginate, ok := s.From.(*Paginate); ok {
+		valueIt, err := NewValueIteratorFromPathStep(paginate.From, qs)
+		if err != nil {
+			return nil, err
+		}
+		pageIt, err := NewPaginateIterator(valueIt, paginate.PageSize, paginate.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		// pages hold distinct values
+		return NewPageWithTotalIterator(pageIt, valueIt.path.Unique()), nil
+	}
+	from, ok := s.From.(PathStep)
+	if !ok {
+		return nil, fmt.Errorf("WithTotal requires a path step or Paginate, got %s", s.From.Type())
+	}
+	valueIt, err := NewValueIteratorFromPathStep(from, qs)
+	if err != nil {
+		return nil, err
+	}
+	total, err := unpaginated(from).BuildPath(qs)
+	if err != nil {
+		return nil, err
+	}
+	return NewWithTotalIterator(valueIt, total), nil
+}
+
+// unpaginated returns step without the limit, skip and page steps it ends with.
+func unpaginated(step PathStep) PathStep {
+	for {
+		switch s := step.(type) {
+		case *Limit:
+			step = s.From
+		case *Skip:
+			step = s.From
+		case *Page:
+			step = s.From
+		default:
+			return step
+		}
+	}
+}
+
+var _ IteratorStep = (*ShortestPath)(nil)
+
+// ShortestPath corresponds to .shortestPath().
+type ShortestPath struct {
+	From PathStep     `json:"from"`
+	To   []quad.Value `json:"to"`
+	Via  PropertyPath `json:"via"`
+}
+
+// Type implements Step.
+func (s *ShortestPath) Type() quad.IRI {
+	return Prefix + "ShortestPath"
+}
+
+// Description implements Step.
+func (s *ShortestPath) Description() string {
+	return "ShortestPath returns a single document listing in order the nodes of the shortest path from a value matched in the query to one of the given values, following the given properties. If there is no such path returns nothing."
+}
+
+// BuildIterator implements IteratorStep
+func (s *ShortestPath) BuildIterator(qs graph.QuadStore) (query.Iterator, error) {
+	fromIt, err := NewValueIteratorFromPathStep(s.From, qs)
+	if err != nil {
+		return nil, err
+	}
+	viaPath, err := s.Via.BuildPath(qs)
+	if err != nil {
+		return nil, err
+	}
+	return NewShortestPathIterator(qs, fromIt, s.To, viaPath), nil
+}
+
+var _ IteratorStep = (*DetectCycles)(nil)
+
+// DetectCycles corresponds to .detectCycles().
+type DetectCycles struct {
+	From PathStep     `json:"from"`
+	Via  PropertyPath `json:"via"`
+}
+
+// Type implements Step.
+func (s *DetectCycles) Type() quad.IRI {
+	return Prefix + "DetectCycles"
+}
+
+// Description implements Step.
+func (s *DetectCycles) Description() string {
+	return "DetectCycles returns the nodes participating in a cycle of the given properties reachable from the values matched in the query"
+}
+
+// BuildIterator implements IteratorStep
+func (s *DetectCycles) BuildIterator(qs graph.QuadStore) (query.Iterator, error) {
+	fromIt, err := NewValueIteratorFromPathStep(s.From, qs)
+	if err != nil {
+		return nil, err
+	}
+	viaPath, err := s.Via.BuildPath(qs)
+	if err != nil {
+		return nil, err
+	}
+	return NewCyclesIterator(qs, fromIt, viaPath), nil
+}
+
+var _ IteratorStep = (*Tail)(nil)
+
+// Tail corresponds to .tail().
+type Tail struct {
+	From  PathStep `json:"from"`
+	Count int      `json:"count"`
+}
+
+// Type implements Step.
+func (s *Tail) Type() quad.IRI {
+	return Prefix + "Tail"
+}
+
+// Description implements Step.
+func (s *Tail) Description() string {
+	return "Tail returns the last count values matched in the query"
+}
+
+// BuildIterator implements IteratorStep
+func (s *Tail) BuildIterator(qs graph.QuadStore) (query.Iterator, error) {
+	if s.Count < 0 {
+		return nil, fmt.Errorf("Tail expects a non negative count, got %d", s.Count)
+	}
+	valueIt, err := NewValueIteratorFromPathStep(s.From, qs)
+	if err != nil {
+		return nil, err
+	}
+	return NewTailIterator(valueIt, s.Count), nil
+}
+
+var _ IteratorStep = (*Sample)(nil)
+
+// Sample corresponds to .sample().
+type Sample struct {
+	From  PathStep `json:"from"`
+	Count int      `json:"count"`
+	Seed  int64    `json:"seed,omitempty"`
+}
+
+// Type implements Step.
+func (s *Sample) Type() quad.IRI {
+	return Prefix + "Sample"
+}
+
+// Description implements Step.
+func (s *Sample) Description() string {
+	return "Sample returns count values randomly chosen from the values matched in the query. If seed is set the same values are chosen every time, otherwise a random seed is used."
+}
+
+// BuildIterator implements IteratorStep
+func (s *Sample) BuildIterator(qs graph.QuadStore) (query.Iterator, error) {
+	if s.Count < 0 {
+		return nil, fmt.Errorf("Sample expects a non negative count, got %d", s.Count)
+	}
+	valueIt, err := NewValueIteratorFromPathStep(s.From, qs)
+	if err != nil {
+		return nil, err
+	}
+	seed := s.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	return NewSampleIterator(valueIt, s.Count, seed), nil
+}
+
+var _ IteratorStep = (*Construct)(nil)
+
+// Construct corresponds to .construct().
+type Construct struct {
+	From      PathStep `json:"from"`
+	Subject   string   `json:"subject"`
+	Predicate quad.IRI `json:"predicate"`
+	Object    string   `json:"object"`
+}
+
+// Type implements Step.
+func (s *Construct) Type() quad.IRI {
+	return Prefix + "Construct"
+}
+
+// Description implements Step.
+func (s *Construct) Description() string {
+	return "Construct returns for each result matched in the query a quad of the value of the subject tag, the predicate and the value of the object tag. Results missing any of the tags are skipped."
+}
+
+// BuildIterator implements IteratorStep
+func (s *Construct) BuildIterator(qs graph.QuadStore) (query.Iterator, error) {
+	valueIt, err := NewValueIteratorFromPathStep(s.From, qs)
+	if err != nil {
+		return nil, err
+	}
+	tagsIt := &TagsIterator{valueIt: valueIt, selected: []string{s.Subject, s.Object}}
+	return NewConstructIterator(tagsIt, s.Subject, s.Predicate, s.Object), nil
+}
+
+var _ IteratorStep = (*ValueType)(nil)
+
+// ValueType corresponds to .valueType().
+type ValueType struct {
+	From PathStep `json:"from"`
+}
+
+// Type implements Step.
+func (s *ValueType) Type() quad.IRI {
+	return Prefix + "ValueType"
+}
+
+// Description implements Step.
+func (s *ValueType) Description() string {
+	return "ValueType returns for each value matched in the query the name of its kind: iri, bnode, string, langString, typedString, int, float, bool or time"
+}
+
+// BuildIterator implements IteratorStep
+func (s *ValueType) BuildIterator(qs graph.QuadStore) (query.Iterator, error) {
+	valueIt, err := NewValueIteratorFromPathStep(s.From, qs)
+	if err != nil {
+		return nil, err
+	}
+	return NewValueTypeIterator(valueIt), nil
+}
+
+var _ IteratorStep = (*Quads)(nil)
+
+// Quads corresponds to .quads().
+type Quads struct {
+	Subject   quad.Value `json:"subject,omitempty" minCardinality:"0"`
+	Predicate quad.Value `json:"predicate,omitempty" minCardinality:"0"`
+	Object    quad.Value `json:"object,omitempty" minCardinality:"0"`
+	Label     quad.Value `json:"label,omitempty" minCardinality:"0"`
+}
+
+// Type implements Step.
+func (s *Quads) Type() quad.IRI {
+	return Prefix + "Quads"
+}
+
+// Description implements Step.
+func (s *Quads) Description() string {
+	return "Quads returns a document with the subject, predicate, object and label of each quad matching the provided values. Directions without a value match any value"
+}
+
+// BuildIterator implements IteratorStep
+func (s *Quads) BuildIterator(qs graph.QuadStore) (query.Iterator, error) {
+	var filters shape.Quads
+	for _, f := range []struct {
+		dir   quad.Direction
+		value quad.Value
+	}{
+		{quad.Subject, s.Subject},
+		{quad.Predicate, s.Predicate},
+		{quad.Object, s.Object},
+		{quad.Label, s.Label},
+	} {
+		if f.value != nil {
+			filters.Intersect(shape.QuadFilter{Dir: f.dir, Values: shape.Lookup{f.value}})
+		}
+	}
+	return NewQuadsIterator(qs, filters.BuildIterator(qs)), nil
+}
+
+var _ IteratorStep = (*Trace)(nil)
+
+// tracePrefix is the prefix of the tags Trace uses internally to collect the traversed values.
+const tracePrefix = Prefix + "trace:"
+
+// Trace corresponds to .trace().
+type Trace struct {
+	From PathStep `json:"from"`
+}
+
+// Type implements Step.
+func (s *Trace) Type() quad.IRI {
+	return Prefix + "Trace"
+}
+
+// Description implements Step.
+func (s *Trace) Description() string {
+	return "Trace returns a document for each value of the from step holding under @path the values traversed to reach it, from the first step to the value itself"
+}
+
+// BuildIterator implements IteratorStep
+func (s *Trace) BuildIterator(qs graph.QuadStore) (query.Iterator, error) {
+	from, tags := traceStep(s.From)
+	valueIt, err := NewValueIteratorFromPathStep(from, qs)
+	if err != nil {
+		return nil, err
+	}
+	tagsIt := &TagsIterator{valueIt: valueIt, selected: tags}
+	return NewTraceIterator(tagsIt, tags), nil
+}
+
+// traversals are the steps resolving to other values than the values of their from step.
+// Other steps, like filters, resolve to values of their from step which are not traced again.
+var traversals = map[reflect.Type]bool{
+	reflect.TypeOf(&Visit{}):                true,
+	reflect.TypeOf(&VisitReverse{}):         true,
+	reflect.TypeOf(&Out{}):                  true,
+	reflect.TypeOf(&In{}):                   true,
+	reflect.TypeOf(&Both{}):                 true,
+	reflect.TypeOf(&Follow{}):               true,
+	reflect.TypeOf(&FollowReverse{}):        true,
+	reflect.TypeOf(&FollowRecursive{}):      true,
+	reflect.TypeOf(&Reverse{}):              true,
+	reflect.TypeOf(&Neighbors{}):            true,
+	reflect.TypeOf(&Coalesce{}):             true,
+	reflect.TypeOf(&Back{}):                 true,
+	reflect.TypeOf(&Count{}):                true,
+	reflect.TypeOf(&Labels{}):               true,
+	reflect.TypeOf(&PropertyNames{}):        true,
+	reflect.TypeOf(&PropertyNamesTo{}):      true,
+	reflect.TypeOf(&ReversePropertyNames{}): true,
+}
+
+// traceStep returns a copy of step tagging the values each traversal of its from chain starts from.
+// The returned tags are ordered from the first step of the chain.
+func traceStep(step PathStep) (PathStep, []string) {
+	v := reflect.ValueOf(step)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return step, nil
+	}
+	f := v.Elem().FieldByName("From")
+	if !f.IsValid() || f.Type() != pathStepType || f.IsNil() {
+		return step, nil
+	}
+	from, tags := traceStep(f.Interface().(PathStep))
+	if traversals[v.Type()] {
+		tag := fmt.Sprintf("%s%d", tracePrefix, len(tags))
+		from = &As{From: from, Name: tag}
+		tags = append(tags, tag)
+	}
+	cp := reflect.New(v.Elem().Type())
+	cp.Elem().Set(v.Elem())
+	cp.Elem().FieldByName("From").Set(reflect.ValueOf(from))
+	return cp.Interface().(PathStep), tags
 }
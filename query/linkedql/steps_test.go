@@ -2,9 +2,14 @@ package linkedql
 
 import (
 	"context"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/cayleygraph/cayley/graph/memstore"
+	"github.com/cayleygraph/cayley/query"
+	"github.com/cayleygraph/cayley/query/path"
+	"github.com/cayleygraph/cayley/query/shape"
 	"github.com/cayleygraph/quad"
 	"github.com/stretchr/testify/require"
 )
@@ -127,6 +132,41 @@ var testCases = []struct {
 			map[string]string{"@value": "4", "@type": "xsd:integer"},
 		},
 	},
+	{
+		name: "Count duplicates",
+		data: []quad.Quad{
+			quad.MakeIRI("alice", "likes", "dan", ""),
+			quad.MakeIRI("bob", "likes", "dan", ""),
+			quad.MakeIRI("carol", "likes", "erin", ""),
+		},
+		query: &Count{
+			From: &Visit{
+				From:       &Vertex{Values: []quad.Value{quad.IRI("alice"), quad.IRI("bob"), quad.IRI("carol")}},
+				Properties: PropertyPath{PropertyIRIString("likes")},
+			},
+		},
+		results: []interface{}{
+			map[string]string{"@value": "3", "@type": "xsd:integer"},
+		},
+	},
+	{
+		name: "Count distinct",
+		data: []quad.Quad{
+			quad.MakeIRI("alice", "likes", "dan", ""),
+			quad.MakeIRI("bob", "likes", "dan", ""),
+			quad.MakeIRI("carol", "likes", "erin", ""),
+		},
+		query: &Count{
+			From: &Visit{
+				From:       &Vertex{Values: []quad.Value{quad.IRI("alice"), quad.IRI("bob"), quad.IRI("carol")}},
+				Properties: PropertyPath{PropertyIRIString("likes")},
+			},
+			Distinct: true,
+		},
+		results: []interface{}{
+			map[string]string{"@value": "2", "@type": "xsd:integer"},
+		},
+	},
 	{
 		name: "Difference",
 		data: singleQuadData,
@@ -144,6 +184,36 @@ var testCases = []struct {
 			map[string]string{"@id": "alice"},
 		},
 	},
+	{
+		name: "Difference multiple steps",
+		data: singleQuadData,
+		query: &Difference{
+			From: &Vertex{},
+			Steps: []PathStep{
+				&Vertex{Values: []quad.Value{quad.IRI("likes")}},
+				&Vertex{Values: []quad.Value{quad.IRI("bob")}},
+			},
+		},
+		results: []interface{}{
+			map[string]string{"@id": "alice"},
+		},
+	},
+	{
+		name: "Except",
+		data: singleQuadData,
+		query: &Except{
+			Difference{
+				From: &Vertex{},
+				Steps: []PathStep{
+					&Vertex{Values: []quad.Value{quad.IRI("alice")}},
+					&Vertex{Values: []quad.Value{quad.IRI("likes")}},
+				},
+			},
+		},
+		results: []interface{}{
+			map[string]string{"@id": "bob"},
+		},
+	},
 	{
 		name: "Filter RegExp",
 		data: []quad.Quad{
@@ -157,6 +227,43 @@ var testCases = []struct {
 			"Alice",
 		},
 	},
+	{
+		name: "Filter RegExp IRIs",
+		data: []quad.Quad{
+			{Subject: quad.IRI("alice"), Predicate: quad.IRI("name"), Object: quad.String("Bob"), Label: nil},
+		},
+		query: &Filter{
+			From:   &Vertex{Values: []quad.Value{}},
+			Filter: &RegExp{Pattern: "^a", IncludeIRIs: false},
+		},
+		results: nil,
+	},
+	{
+		name: "Filter RegExp Include IRIs",
+		data: []quad.Quad{
+			{Subject: quad.IRI("alice"), Predicate: quad.IRI("name"), Object: quad.String("Bob"), Label: nil},
+		},
+		query: &Filter{
+			From:   &Vertex{Values: []quad.Value{}},
+			Filter: &RegExp{Pattern: "^a", IncludeIRIs: true},
+		},
+		results: []interface{}{
+			map[string]string{"@id": "alice"},
+		},
+	},
+	{
+		name: "Filter RegExp Case Insensitive",
+		data: []quad.Quad{
+			{Subject: quad.IRI("alice"), Predicate: quad.IRI("name"), Object: quad.String("Alice"), Label: nil},
+		},
+		query: &Filter{
+			From:   &Vertex{Values: []quad.Value{}},
+			Filter: &RegExp{Pattern: "^al", CaseInsensitive: true},
+		},
+		results: []interface{}{
+			"Alice",
+		},
+	},
 	{
 		name: "Filter Like",
 		data: []quad.Quad{
@@ -170,6 +277,90 @@ var testCases = []struct {
 			map[string]string{"@id": "alice"},
 		},
 	},
+	{
+		name: "Filter Like Case Insensitive",
+		data: []quad.Quad{
+			{Subject: quad.IRI("bob"), Predicate: quad.IRI("name"), Object: quad.String("Alice"), Label: nil},
+		},
+		query: &Filter{
+			From:   &Vertex{Values: []quad.Value{}},
+			Filter: &Like{Pattern: "a%", CaseInsensitive: true},
+		},
+		results: []interface{}{
+			"Alice",
+		},
+	},
+	{
+		name: "Filter Like Case Sensitive",
+		data: []quad.Quad{
+			{Subject: quad.IRI("bob"), Predicate: quad.IRI("name"), Object: quad.String("Alice"), Label: nil},
+		},
+		query: &Filter{
+			From:   &Vertex{Values: []quad.Value{}},
+			Filter: &Like{Pattern: "a%"},
+		},
+		results: nil,
+	},
+	{
+		name: "Filter NotLike",
+		data: []quad.Quad{
+			{Subject: quad.IRI("alice"), Predicate: quad.IRI("name"), Object: quad.String("Alice"), Label: nil},
+			{Subject: quad.IRI("anna"), Predicate: quad.IRI("name"), Object: quad.LangString{Value: "Anna", Lang: "en"}, Label: nil},
+			{Subject: quad.IRI("bob"), Predicate: quad.IRI("name"), Object: quad.String("Bob"), Label: nil},
+			{Subject: quad.IRI("bob"), Predicate: quad.IRI("age"), Object: quad.Int(10), Label: nil},
+		},
+		query: &Filter{
+			From:   &Vertex{Values: []quad.Value{}},
+			Filter: &NotLike{Pattern: "A%"},
+		},
+		results: []interface{}{
+			"Bob",
+		},
+	},
+	{
+		name: "Filter Contains",
+		data: []quad.Quad{
+			{Subject: quad.IRI("alice"), Predicate: quad.IRI("name"), Object: quad.String("Alice"), Label: nil},
+			{Subject: quad.IRI("alice"), Predicate: quad.IRI("title"), Object: quad.LangString{Value: "Malice", Lang: "en"}, Label: nil},
+			{Subject: quad.IRI("alice"), Predicate: quad.IRI("age"), Object: quad.Int(10), Label: nil},
+		},
+		query: &Filter{
+			From:   &Vertex{Values: []quad.Value{}},
+			Filter: &Contains{Pattern: "lic"},
+		},
+		results: []interface{}{
+			"Alice",
+			map[string]string{"@value": "Malice", "@language": "en"},
+		},
+	},
+	{
+		name: "Filter StartsWith",
+		data: []quad.Quad{
+			{Subject: quad.IRI("alice"), Predicate: quad.IRI("name"), Object: quad.String("Alice"), Label: nil},
+			{Subject: quad.IRI("bob"), Predicate: quad.IRI("name"), Object: quad.String("Bob"), Label: nil},
+		},
+		query: &Filter{
+			From:   &Vertex{Values: []quad.Value{}},
+			Filter: &StartsWith{Pattern: "Al"},
+		},
+		results: []interface{}{
+			"Alice",
+		},
+	},
+	{
+		name: "Filter EndsWith",
+		data: []quad.Quad{
+			{Subject: quad.IRI("alice"), Predicate: quad.IRI("name"), Object: quad.String("Alice"), Label: nil},
+			{Subject: quad.IRI("bob"), Predicate: quad.IRI("name"), Object: quad.String("Bob"), Label: nil},
+		},
+		query: &Filter{
+			From:   &Vertex{Values: []quad.Value{}},
+			Filter: &EndsWith{Pattern: "ice"},
+		},
+		results: []interface{}{
+			"Alice",
+		},
+	},
 	{
 		name: "Filter LessThan",
 		data: []quad.Quad{
@@ -231,202 +422,2713 @@ var testCases = []struct {
 		},
 	},
 	{
-		name: "Has",
-		data: singleQuadData,
-		query: &Has{
-			From: &Vertex{
-				Values: []quad.Value{},
-			},
-			Property: PropertyPath{&Vertex{
-				Values: []quad.Value{quad.IRI("likes")},
-			}},
-			Values: []quad.Value{quad.IRI("bob")},
+		name: "Filter LessThan int and float",
+		data: []quad.Quad{
+			quad.Make(quad.IRI("a"), quad.IRI("value"), quad.Int(5), nil),
+			quad.Make(quad.IRI("b"), quad.IRI("value"), quad.Float(6.5), nil),
+		},
+		query: &LessThan{
+			From:  &Vertex{Values: []quad.Value{}},
+			Value: quad.Float(5.5),
 		},
 		results: []interface{}{
-			map[string]string{"@id": "alice"},
+			map[string]string{"@value": "5", "@type": "xsd:integer"},
 		},
 	},
 	{
-		name: "HasReverse",
-		data: singleQuadData,
-		query: &HasReverse{
-			From: &Vertex{
-				Values: []quad.Value{},
-			},
-			Property: PropertyPath{&Vertex{
-				Values: []quad.Value{quad.IRI("likes")},
-			}},
-			Values: []quad.Value{quad.IRI("alice")},
+		name: "Filter LessThanEquals int and float",
+		data: []quad.Quad{
+			quad.Make(quad.IRI("a"), quad.IRI("value"), quad.Int(5), nil),
+			quad.Make(quad.IRI("b"), quad.IRI("value"), quad.Float(6.5), nil),
+		},
+		query: &LessThanEquals{
+			From:  &Vertex{Values: []quad.Value{}},
+			Value: quad.Float(5),
 		},
 		results: []interface{}{
-			map[string]string{"@id": "bob"},
+			map[string]string{"@value": "5", "@type": "xsd:integer"},
 		},
 	},
 	{
-		name: "ViewReverse",
-		data: singleQuadData,
-		query: &VisitReverse{
-			From:       &Vertex{Values: []quad.Value{}},
-			Properties: PropertyPath{&Vertex{Values: []quad.Value{quad.IRI("likes")}}},
+		name: "Filter GreaterThan int and float",
+		data: []quad.Quad{
+			quad.Make(quad.IRI("a"), quad.IRI("value"), quad.Int(5), nil),
+			quad.Make(quad.IRI("b"), quad.IRI("value"), quad.Float(6.5), nil),
+		},
+		query: &GreaterThan{
+			From:  &Vertex{Values: []quad.Value{}},
+			Value: quad.Int(5),
 		},
 		results: []interface{}{
-			map[string]string{"@id": "alice"},
+			map[string]string{"@value": "6.5E+00", "@type": "xsd:double"},
 		},
 	},
 	{
-		name: "PropertyNames",
-		data: singleQuadData,
-		query: &PropertyNames{
-			From: &Vertex{Values: []quad.Value{}},
+		name: "Filter GreaterThanEquals float and int",
+		data: []quad.Quad{
+			quad.Make(quad.IRI("a"), quad.IRI("value"), quad.Int(5), nil),
+			quad.Make(quad.IRI("b"), quad.IRI("value"), quad.Float(6.5), nil),
+		},
+		query: &GreaterThanEquals{
+			From:  &Vertex{Values: []quad.Value{}},
+			Value: quad.Float(5.5),
 		},
 		results: []interface{}{
-			map[string]string{"@id": "likes"},
+			map[string]string{"@value": "6.5E+00", "@type": "xsd:double"},
 		},
 	},
 	{
-		name: "Intersect",
+		name: "Filter LessThan date times across timezones",
 		data: []quad.Quad{
-			quad.MakeIRI("bob", "likes", "alice", ""),
-			quad.MakeIRI("dani", "likes", "alice", ""),
+			quad.Make(quad.IRI("a"), quad.IRI("at"), quad.TypedString{Value: "2020-01-01T10:00:00+02:00", Type: "xsd:dateTime"}, nil),
+			quad.Make(quad.IRI("b"), quad.IRI("at"), quad.TypedString{Value: "2020-01-01T09:00:00Z", Type: "xsd:dateTime"}, nil),
 		},
-		query: &Intersect{
+		query: &LessThan{
 			From: &Visit{
-				From: &Vertex{Values: []quad.Value{quad.IRI("bob")}},
-				Properties: PropertyPath{&Vertex{
-					Values: []quad.Value{quad.IRI("likes")},
-				}},
-			},
-			Steps: []PathStep{
-				&Visit{
-					From:       &Vertex{Values: []quad.Value{quad.IRI("bob")}},
-					Properties: PropertyPath{&Vertex{Values: []quad.Value{quad.IRI("likes")}}},
-				},
+				From:       &Vertex{Values: []quad.Value{}},
+				Properties: PropertyPath{PropertyIRIString("at")},
 			},
+			Value: quad.TypedString{Value: "2020-01-01T08:30:00Z", Type: "xsd:dateTime"},
+		},
+		results: []interface{}{
+			map[string]string{"@value": "2020-01-01T10:00:00+02:00", "@type": "xsd:dateTime"},
+		},
+	},
+	{
+		name: "HasAll",
+		data: []quad.Quad{
+			quad.MakeIRI("alice", "skill", "go", ""),
+			quad.MakeIRI("alice", "skill", "rust", ""),
+			quad.MakeIRI("bob", "skill", "go", ""),
+		},
+		query: &HasAll{
+			From:     &Vertex{},
+			Property: PropertyPath{PropertyIRIString("skill")},
+			Values:   []quad.Value{quad.IRI("go"), quad.IRI("rust")},
 		},
 		results: []interface{}{
 			map[string]string{"@id": "alice"},
 		},
 	},
 	{
-		name: "Is",
-		data: singleQuadData,
-		query: &Is{
-			Values: []quad.Value{quad.IRI("bob")},
-			From: &Visit{
-				From: &Vertex{Values: []quad.Value{quad.IRI("alice")}},
-				Properties: PropertyPath{&Vertex{
-					Values: []quad.Value{quad.IRI("likes")},
-				}},
+		name: "Has any of the values",
+		data: []quad.Quad{
+			quad.MakeIRI("alice", "skill", "go", ""),
+			quad.MakeIRI("alice", "skill", "rust", ""),
+			quad.MakeIRI("bob", "skill", "go", ""),
+		},
+		query: &Unique{
+			From: &Has{
+				From:     &Vertex{},
+				Property: PropertyPath{PropertyIRIString("skill")},
+				Values:   []quad.Value{quad.IRI("go"), quad.IRI("rust")},
 			},
 		},
 		results: []interface{}{
+			map[string]string{"@id": "alice"},
 			map[string]string{"@id": "bob"},
 		},
 	},
 	{
-		name: "Limit",
-		data: singleQuadData,
-		query: &Limit{
-			Limit: 2,
-			From: &Vertex{
-				Values: []quad.Value{},
+		name: "Coalesce",
+		data: []quad.Quad{
+			quad.Make(quad.IRI("alice"), quad.IRI("displayName"), quad.String("Al"), nil),
+			quad.Make(quad.IRI("alice"), quad.IRI("name"), quad.String("Alice"), nil),
+			quad.Make(quad.IRI("bob"), quad.IRI("name"), quad.String("Bob"), nil),
+			quad.Make(quad.IRI("carol"), quad.IRI("likes"), quad.IRI("bob"), nil),
+		},
+		query: &Coalesce{
+			From: &Vertex{Values: []quad.Value{quad.IRI("alice"), quad.IRI("bob"), quad.IRI("carol")}},
+			Properties: []PropertyPath{
+				{PropertyIRIString("displayName")},
+				{PropertyIRIString("name")},
 			},
 		},
 		results: []interface{}{
-			map[string]string{"@id": "alice"},
-			map[string]string{"@id": "likes"},
+			"Al",
+			"Bob",
 		},
 	},
 	{
-		name: "View",
-		data: singleQuadData,
-		query: &Visit{
-			From:       &Vertex{Values: []quad.Value{}},
-			Properties: PropertyPath{&Vertex{Values: []quad.Value{quad.IRI("likes")}}},
+		name: "Coalesce Three Properties",
+		data: []quad.Quad{
+			quad.Make(quad.IRI("alice"), quad.IRI("displayName"), quad.String("Al"), nil),
+			quad.Make(quad.IRI("alice"), quad.IRI("login"), quad.String("al1"), nil),
+			quad.Make(quad.IRI("bob"), quad.IRI("name"), quad.String("Bob"), nil),
+			quad.Make(quad.IRI("bob"), quad.IRI("login"), quad.String("bob1"), nil),
+			quad.Make(quad.IRI("carol"), quad.IRI("login"), quad.String("carol1"), nil),
+		},
+		query: &Coalesce{
+			From: &Vertex{Values: []quad.Value{quad.IRI("alice"), quad.IRI("bob"), quad.IRI("carol")}},
+			Properties: []PropertyPath{
+				{PropertyIRIString("displayName")},
+				{PropertyIRIString("name")},
+				{PropertyIRIString("login")},
+			},
 		},
 		results: []interface{}{
-			map[string]string{"@id": "bob"},
+			"Al",
+			"Bob",
+			"carol1",
 		},
 	},
 	{
-		name: "PropertyNames",
-		data: singleQuadData,
-		query: &PropertyNames{
-			From: &Vertex{Values: []quad.Value{}},
+		name: "WithinRef",
+		data: []quad.Quad{
+			quad.MakeIRI("alice", "likes", "bob", ""),
+			quad.MakeIRI("alice", "likes", "carol", ""),
+			quad.MakeIRI("alice", "likes", "dan", ""),
+		},
+		query: &WithinRef{
+			From: &Visit{
+				From:       &Vertex{Values: []quad.Value{quad.IRI("alice")}},
+				Properties: PropertyPath{PropertyIRIString("likes")},
+			},
+			Source: &Vertex{Values: []quad.Value{quad.IRI("carol"), quad.IRI("dan"), quad.IRI("erin")}},
 		},
 		results: []interface{}{
-			map[string]string{"@id": "likes"},
+			map[string]string{"@id": "carol"},
+			map[string]string{"@id": "dan"},
 		},
 	},
 	{
-		name: "Properties",
+		name: "Exists",
 		data: singleQuadData,
-		query: &Select{
-			From: &Properties{
-				From:  &Vertex{Values: []quad.Value{}},
-				Names: []quad.IRI{quad.IRI("likes")},
+		query: &Exists{
+			From: &Has{
+				From:     &Vertex{},
+				Property: PropertyPath{PropertyIRIString("likes")},
+				Values:   []quad.Value{quad.IRI("bob")},
 			},
 		},
-		results: []interface{}{map[string]interface{}{"likes": map[string]string{"@id": "bob"}}},
+		results: []interface{}{
+			map[string]string{"@value": "True", "@type": "xsd:boolean"},
+		},
 	},
 	{
-		name: "ReversePropertyNamesAs",
+		name: "Exists No Match",
 		data: singleQuadData,
-		query: &Select{
-			From: &ReversePropertyNamesAs{
-				From: &Vertex{Values: []quad.Value{}},
+		query: &Exists{
+			From: &Has{
+				From:     &Vertex{},
+				Property: PropertyPath{PropertyIRIString("likes")},
+				Values:   []quad.Value{quad.IRI("alice")},
+			},
+		},
+		results: []interface{}{
+			map[string]string{"@value": "False", "@type": "xsd:boolean"},
+		},
+	},
+	{
+		name: "WithTotal",
+		data: []quad.Quad{
+			quad.MakeIRI("alice", "likes", "bob", ""),
+			quad.MakeIRI("bob", "likes", "dan", ""),
+			quad.MakeIRI("dan", "likes", "eve", ""),
+			quad.MakeIRI("eve", "follows", "alice", ""),
+		},
+		query: &WithTotal{
+			From: &Limit{
+				From: &Skip{
+					From: &Has{
+						From:     &Vertex{},
+						Property: PropertyPath{PropertyIRIString("likes")},
+					},
+					Offset: 1,
+				},
+				Limit: 1,
+			},
+		},
+		results: []interface{}{
+			map[string]interface{}{
+				"total": int64(3),
+				"items": []interface{}{
+					map[string]string{"@id": "bob"},
+				},
+			},
+		},
+	},
+	{
+		name: "WithTotal Page",
+		data: singleQuadData,
+		query: &WithTotal{
+			From: &Page{
+				From:   &Vertex{Values: []quad.Value{quad.IRI("alice"), quad.IRI("bob")}},
+				Number: 1,
+				Size:   1,
+			},
+		},
+		results: []interface{}{
+			map[string]interface{}{
+				"total": int64(2),
+				"items": []interface{}{
+					map[string]string{"@id": "alice"},
+				},
+			},
+		},
+	},
+	{
+		name: "WithTotal Paginate",
+		data: []quad.Quad{
+			quad.MakeIRI("alice", "likes", "bob", ""),
+			quad.MakeIRI("alice", "likes", "dan", ""),
+			quad.MakeIRI("bob", "likes", "dan", ""),
+		},
+		query: &WithTotal{
+			From: &Paginate{
+				From: &Visit{
+					From:       &Vertex{},
+					Properties: PropertyPath{PropertyIRIString("likes")},
+				},
+				PageSize: 1,
+			},
+		},
+		results: []interface{}{
+			map[string]interface{}{
+				"total": int64(2),
+				"items": []interface{}{
+					map[string]string{"@id": "bob"},
+				},
+				"nextCursor": "eyJAaWQiOiJib2IifQ",
+			},
+		},
+	},
+	{
+		name: "WithTotal Visit Has",
+		data: []quad.Quad{
+			quad.MakeIRI("alice", "likes", "alice", ""),
+			quad.MakeIRI("alice", "likes", "bob", ""),
+		},
+		query: &WithTotal{
+			From: &Visit{
+				From: &Has{
+					From:     &Vertex{Values: []quad.Value{quad.IRI("alice")}},
+					Property: PropertyPath{PropertyIRIString("likes")},
+				},
+				Properties: PropertyPath{PropertyIRIString("likes")},
+			},
+		},
+		results: []interface{}{
+			map[string]interface{}{
+				"total": int64(2),
+				"items": []interface{}{
+					map[string]string{"@id": "alice"},
+					map[string]string{"@id": "bob"},
+				},
+			},
+		},
+	},
+	{
+		name: "Is Normalize",
+		data: []quad.Quad{
+			quad.MakeIRI("alice", "type", "schema:Person", ""),
+			quad.MakeIRI("bob", "type", "http://schema.org/Person", ""),
+			quad.MakeIRI("dan", "type", "schema:Organization", ""),
+		},
+		query: &Is{
+			From: &Visit{
+				From:       &Vertex{Values: []quad.Value{quad.IRI("alice"), quad.IRI("bob"), quad.IRI("dan")}},
+				Properties: PropertyPath{PropertyIRIString("type")},
+			},
+			Values:    []quad.Value{quad.IRI("http://schema.org/Person")},
+			Normalize: true,
+		},
+		results: []interface{}{
+			map[string]string{"@id": "schema:Person"},
+			map[string]string{"@id": "http://schema.org/Person"},
+		},
+	},
+	{
+		name: "Equals Normalize",
+		data: []quad.Quad{
+			quad.MakeIRI("alice", "type", "schema:Person", ""),
+			quad.MakeIRI("bob", "type", "http://schema.org/Person", ""),
+		},
+		query: &Equals{
+			From: &Visit{
+				From:       &Vertex{Values: []quad.Value{quad.IRI("alice"), quad.IRI("bob")}},
+				Properties: PropertyPath{PropertyIRIString("type")},
+			},
+			Value:     quad.IRI("schema:Person"),
+			Normalize: true,
+		},
+		results: []interface{}{
+			map[string]string{"@id": "schema:Person"},
+			map[string]string{"@id": "http://schema.org/Person"},
+		},
+	},
+	{
+		name: "Quads",
+		data: []quad.Quad{
+			quad.MakeIRI("alice", "likes", "bob", ""),
+			quad.MakeIRI("alice", "likes", "dan", "work"),
+			quad.MakeIRI("alice", "name", "Alice", ""),
+			quad.MakeIRI("bob", "likes", "alice", ""),
+		},
+		query: &Quads{
+			Subject:   quad.IRI("alice"),
+			Predicate: quad.IRI("likes"),
+		},
+		results: []interface{}{
+			map[string]interface{}{
+				"subject":   map[string]string{"@id": "alice"},
+				"predicate": map[string]string{"@id": "likes"},
+				"object":    map[string]string{"@id": "bob"},
+				"label":     nil,
+			},
+			map[string]interface{}{
+				"subject":   map[string]string{"@id": "alice"},
+				"predicate": map[string]string{"@id": "likes"},
+				"object":    map[string]string{"@id": "dan"},
+				"label":     map[string]string{"@id": "work"},
+			},
+		},
+	},
+	{
+		name: "Has",
+		data: singleQuadData,
+		query: &Has{
+			From: &Vertex{
+				Values: []quad.Value{},
+			},
+			Property: PropertyPath{&Vertex{
+				Values: []quad.Value{quad.IRI("likes")},
+			}},
+			Values: []quad.Value{quad.IRI("bob")},
+		},
+		results: []interface{}{
+			map[string]string{"@id": "alice"},
+		},
+	},
+	{
+		name: "HasReverse",
+		data: singleQuadData,
+		query: &HasReverse{
+			From: &Vertex{
+				Values: []quad.Value{},
+			},
+			Property: PropertyPath{&Vertex{
+				Values: []quad.Value{quad.IRI("likes")},
+			}},
+			Values: []quad.Value{quad.IRI("alice")},
+		},
+		results: []interface{}{
+			map[string]string{"@id": "bob"},
+		},
+	},
+	{
+		name: "ViewReverse",
+		data: singleQuadData,
+		query: &VisitReverse{
+			From:       &Vertex{Values: []quad.Value{}},
+			Properties: PropertyPath{&Vertex{Values: []quad.Value{quad.IRI("likes")}}},
+		},
+		results: []interface{}{
+			map[string]string{"@id": "alice"},
+		},
+	},
+	{
+		name: "ViewReverse with property tag",
+		data: []quad.Quad{
+			quad.MakeIRI("alice", "likes", "bob", ""),
+			quad.MakeIRI("carol", "follows", "bob", ""),
+		},
+		query: &Select{
+			From: &As{
+				From: &VisitReverse{
+					From:        &Vertex{Values: []quad.Value{quad.IRI("bob")}},
+					Properties:  PropertyPath{&Vertex{Values: []quad.Value{quad.IRI("likes"), quad.IRI("follows")}}},
+					PropertyTag: "via",
+				},
+				Name: "source",
+			},
+		},
+		results: []interface{}{
+			map[string]interface{}{
+				"source": map[string]string{"@id": "alice"},
+				"via":    map[string]string{"@id": "likes"},
+			},
+			map[string]interface{}{
+				"source": map[string]string{"@id": "carol"},
+				"via":    map[string]string{"@id": "follows"},
+			},
+		},
+	},
+	{
+		name: "Reverse",
+		data: singleQuadData,
+		query: &Reverse{
+			From: &Vertex{Values: []quad.Value{}},
+			Step: &Visit{
+				From:       &Placeholder{},
+				Properties: PropertyPath{&Vertex{Values: []quad.Value{quad.IRI("likes")}}},
+			},
+		},
+		results: []interface{}{
+			map[string]string{"@id": "alice"},
+		},
+	},
+	{
+		name: "Reverse of VisitReverse",
+		data: singleQuadData,
+		query: &Reverse{
+			From: &Vertex{Values: []quad.Value{quad.IRI("alice")}},
+			Step: &VisitReverse{
+				From:       &Placeholder{},
+				Properties: PropertyPath{&Vertex{Values: []quad.Value{quad.IRI("likes")}}},
+			},
+		},
+		results: []interface{}{
+			map[string]string{"@id": "bob"},
+		},
+	},
+	{
+		name: "PropertyNamesTo",
+		data: []quad.Quad{
+			quad.MakeIRI("alice", "likes", "bob", ""),
+			quad.MakeIRI("alice", "knows", "carol", ""),
+			quad.MakeIRI("dan", "follows", "bob", ""),
+		},
+		query: &PropertyNamesTo{
+			From: &Vertex{Values: []quad.Value{quad.IRI("alice")}},
+			To:   []quad.Value{quad.IRI("bob")},
+		},
+		results: []interface{}{
+			map[string]string{"@id": "likes"},
+		},
+	},
+	{
+		name: "PropertyNamesTo without match",
+		data: singleQuadData,
+		query: &PropertyNamesTo{
+			From: &Vertex{Values: []quad.Value{quad.IRI("alice")}},
+			To:   []quad.Value{quad.IRI("carol")},
+		},
+		results: nil,
+	},
+	{
+		name: "PropertyNames",
+		data: singleQuadData,
+		query: &PropertyNames{
+			From: &Vertex{Values: []quad.Value{}},
+		},
+		results: []interface{}{
+			map[string]string{"@id": "likes"},
+		},
+	},
+	{
+		name: "Intersect",
+		data: []quad.Quad{
+			quad.MakeIRI("bob", "likes", "alice", ""),
+			quad.MakeIRI("dani", "likes", "alice", ""),
+		},
+		query: &Intersect{
+			From: &Visit{
+				From: &Vertex{Values: []quad.Value{quad.IRI("bob")}},
+				Properties: PropertyPath{&Vertex{
+					Values: []quad.Value{quad.IRI("likes")},
+				}},
+			},
+			Steps: []PathStep{
+				&Visit{
+					From:       &Vertex{Values: []quad.Value{quad.IRI("bob")}},
+					Properties: PropertyPath{&Vertex{Values: []quad.Value{quad.IRI("likes")}}},
+				},
+			},
+		},
+		results: []interface{}{
+			map[string]string{"@id": "alice"},
+		},
+	},
+	{
+		name: "Intersect three steps",
+		data: []quad.Quad{
+			quad.MakeIRI("alice", "likes", "bob", ""),
+			quad.MakeIRI("alice", "likes", "carol", ""),
+			quad.MakeIRI("alice", "likes", "dan", ""),
+			quad.MakeIRI("bob", "likes", "carol", ""),
+			quad.MakeIRI("bob", "likes", "dan", ""),
+			quad.MakeIRI("erin", "likes", "dan", ""),
+		},
+		query: &Intersect{
+			From: &Visit{
+				From:       &Vertex{Values: []quad.Value{quad.IRI("alice")}},
+				Properties: PropertyPath{PropertyIRIString("likes")},
+			},
+			Steps: []PathStep{
+				&Visit{
+					From:       &Vertex{Values: []quad.Value{quad.IRI("bob")}},
+					Properties: PropertyPath{PropertyIRIString("likes")},
+				},
+				&Visit{
+					From:       &Vertex{Values: []quad.Value{quad.IRI("erin")}},
+					Properties: PropertyPath{PropertyIRIString("likes")},
+				},
+			},
+		},
+		results: []interface{}{
+			map[string]string{"@id": "dan"},
+		},
+	},
+	{
+		name: "Intersect empty step",
+		data: singleQuadData,
+		query: &Intersect{
+			From: &Vertex{},
+			Steps: []PathStep{
+				&Visit{
+					From:       &Vertex{Values: []quad.Value{quad.IRI("bob")}},
+					Properties: PropertyPath{PropertyIRIString("likes")},
+				},
+				&Vertex{Values: []quad.Value{quad.IRI("alice")}},
+			},
+		},
+		results: nil,
+	},
+	{
+		name: "Intersect Duplicates",
+		data: []quad.Quad{
+			quad.MakeIRI("alice", "likes", "carol", ""),
+			quad.MakeIRI("bob", "likes", "carol", ""),
+			quad.MakeIRI("bob", "likes", "dan", ""),
+			quad.MakeIRI("erin", "likes", "carol", ""),
+			quad.MakeIRI("erin", "likes", "dan", ""),
+		},
+		query: &Intersect{
+			From: &Visit{
+				From:       &Vertex{Values: []quad.Value{quad.IRI("alice"), quad.IRI("bob")}},
+				Properties: PropertyPath{PropertyIRIString("likes")},
+			},
+			Steps: []PathStep{
+				&Visit{
+					From:       &Vertex{Values: []quad.Value{quad.IRI("erin")}},
+					Properties: PropertyPath{PropertyIRIString("likes")},
+				},
+			},
+		},
+		results: []interface{}{
+			map[string]string{"@id": "carol"},
+			map[string]string{"@id": "carol"},
+			map[string]string{"@id": "dan"},
+		},
+	},
+	{
+		name: "Visit Has",
+		data: []quad.Quad{
+			quad.MakeIRI("alice", "likes", "alice", ""),
+			quad.MakeIRI("alice", "likes", "bob", ""),
+		},
+		query: &Visit{
+			From: &Has{
+				From:     &Vertex{Values: []quad.Value{quad.IRI("alice")}},
+				Property: PropertyPath{PropertyIRIString("likes")},
+			},
+			Properties: PropertyPath{PropertyIRIString("likes")},
+		},
+		results: []interface{}{
+			map[string]string{"@id": "alice"},
+			map[string]string{"@id": "bob"},
+		},
+	},
+	{
+		name: "Is",
+		data: singleQuadData,
+		query: &Is{
+			Values: []quad.Value{quad.IRI("bob")},
+			From: &Visit{
+				From: &Vertex{Values: []quad.Value{quad.IRI("alice")}},
+				Properties: PropertyPath{&Vertex{
+					Values: []quad.Value{quad.IRI("likes")},
+				}},
+			},
+		},
+		results: []interface{}{
+			map[string]string{"@id": "bob"},
+		},
+	},
+	{
+		name: "Limit",
+		data: singleQuadData,
+		query: &Limit{
+			Limit: 2,
+			From: &Vertex{
+				Values: []quad.Value{},
+			},
+		},
+		results: []interface{}{
+			map[string]string{"@id": "alice"},
+			map[string]string{"@id": "likes"},
+		},
+	},
+	{
+		name: "Limit zero",
+		data: singleQuadData,
+		query: &Limit{
+			Limit: 0,
+			From:  &Vertex{},
+		},
+		results: []interface{}{
+			map[string]string{"@id": "alice"},
+			map[string]string{"@id": "likes"},
+			map[string]string{"@id": "bob"},
+		},
+	},
+	{
+		name: "Limit negative",
+		data: singleQuadData,
+		query: &Limit{
+			Limit: -1,
+			From:  &Vertex{},
+		},
+		results: []interface{}{
+			map[string]string{"@id": "alice"},
+			map[string]string{"@id": "likes"},
+			map[string]string{"@id": "bob"},
+		},
+	},
+	{
+		name: "View",
+		data: singleQuadData,
+		query: &Visit{
+			From:       &Vertex{Values: []quad.Value{}},
+			Properties: PropertyPath{&Vertex{Values: []quad.Value{quad.IRI("likes")}}},
+		},
+		results: []interface{}{
+			map[string]string{"@id": "bob"},
+		},
+	},
+	{
+		name: "PropertyNames",
+		data: singleQuadData,
+		query: &PropertyNames{
+			From: &Vertex{Values: []quad.Value{}},
+		},
+		results: []interface{}{
+			map[string]string{"@id": "likes"},
+		},
+	},
+	{
+		name: "Properties",
+		data: singleQuadData,
+		query: &Select{
+			From: &Properties{
+				From:  &Vertex{Values: []quad.Value{}},
+				Names: []quad.IRI{quad.IRI("likes")},
+			},
+		},
+		results: []interface{}{map[string]interface{}{"likes": map[string]string{"@id": "bob"}}},
+	},
+	{
+		name: "ReversePropertyNamesAs",
+		data: singleQuadData,
+		query: &Select{
+			From: &ReversePropertyNamesAs{
+				From: &Vertex{Values: []quad.Value{}},
+				Tag:  "predicate",
+			},
+		},
+		results: []interface{}{map[string]interface{}{"predicate": map[string]string{"@id": "likes"}}},
+	},
+	{
+		name: "PropertyNamesAs",
+		data: singleQuadData,
+		query: &Select{
+			From: &PropertyNamesAs{
+				From: &Vertex{Values: []quad.Value{}},
 				Tag:  "predicate",
 			},
 		},
-		results: []interface{}{map[string]interface{}{"predicate": map[string]string{"@id": "likes"}}},
+		results: []interface{}{map[string]interface{}{"predicate": map[string]string{"@id": "likes"}}},
+	},
+	{
+		name: "ReverseProperties",
+		data: singleQuadData,
+		query: &Select{
+			From: &ReverseProperties{
+				From:  &Vertex{Values: []quad.Value{}},
+				Names: []quad.IRI{quad.IRI("likes")},
+			},
+		},
+		results: []interface{}{map[string]interface{}{"likes": map[string]string{"@id": "alice"}}},
+	},
+	{
+		name: "Skip",
+		data: singleQuadData,
+		query: &Skip{
+			Offset: 2,
+			From: &Vertex{
+				Values: []quad.Value{},
+			},
+		},
+		results: []interface{}{
+			map[string]string{"@id": "bob"},
+		},
+	},
+	{
+		name: "Union",
+		data: singleQuadData,
+		query: &Union{
+			From: &Vertex{
+				Values: []quad.Value{quad.IRI("alice")},
+			},
+			Steps: []PathStep{
+				&Vertex{
+					Values: []quad.Value{quad.IRI("bob")},
+				},
+			},
+		},
+		results: []interface{}{
+			map[string]string{"@id": "alice"},
+			map[string]string{"@id": "bob"},
+		},
+	},
+	{
+		name: "SelectFirst",
+		data: singleQuadData,
+		query: &SelectFirst{
+			From: &As{
+				From: &Visit{
+					From: &As{
+						Name: "liker",
+						From: &Vertex{},
+					},
+					Properties: PropertyPath{&Vertex{Values: []quad.Value{quad.IRI("likes")}}},
+				},
+				Name: "liked",
+			},
+		},
+		results: []interface{}{map[string]interface{}{"liked": map[string]string{"@id": "bob"}, "liker": map[string]string{"@id": "alice"}}},
+	},
+	{
+		name: "Unique",
+		data: singleQuadData,
+		query: &Unique{
+			From: &Vertex{
+				Values: []quad.Value{quad.IRI("alice"), quad.IRI("alice"), quad.IRI("bob")},
+			},
+		},
+		results: []interface{}{
+			map[string]string{"@id": "alice"},
+			map[string]string{"@id": "bob"},
+		},
+	},
+	{
+		name: "Order",
+		data: singleQuadData,
+		query: &Order{
+			From: &Vertex{},
+		},
+		results: []interface{}{
+			map[string]string{"@id": "alice"},
+			map[string]string{"@id": "bob"},
+			map[string]string{"@id": "likes"},
+		},
+	},
+	{
+		name: "Order Descending",
+		data: singleQuadData,
+		query: &Order{
+			From:       &Vertex{},
+			Descending: true,
+		},
+		results: []interface{}{
+			map[string]string{"@id": "likes"},
+			map[string]string{"@id": "bob"},
+			map[string]string{"@id": "alice"},
+		},
+	},
+	{
+		name: "Vertex Blank Node",
+		data: []quad.Quad{
+			quad.Make(quad.BNode("b1"), quad.IRI("knows"), quad.BNode("b2"), nil),
+			quad.Make(quad.BNode("b3"), quad.IRI("knows"), quad.BNode("b4"), nil),
+		},
+		query: &Visit{
+			From:       &Vertex{Values: []quad.Value{quad.BNode("b1")}},
+			Properties: PropertyPath{PropertyIRIString("knows")},
+		},
+		results: []interface{}{
+			map[string]string{"@id": "_:b2"},
+		},
+	},
+	{
+		name: "MinDegree",
+		data: []quad.Quad{
+			quad.MakeIRI("alice", "likes", "bob", ""),
+			quad.MakeIRI("alice", "likes", "dan", ""),
+			quad.MakeIRI("bob", "likes", "alice", ""),
+			quad.MakeIRI("dan", "likes", "alice", ""),
+			quad.MakeIRI("dan", "likes", "bob", ""),
+			quad.MakeIRI("dan", "likes", "eve", ""),
+			quad.MakeIRI("eve", "follows", "alice", ""),
+			quad.MakeIRI("eve", "follows", "bob", ""),
+		},
+		query: &MinDegree{
+			From:     &Vertex{},
+			Property: PropertyPath{PropertyIRIString("likes")},
+			Count:    2,
+		},
+		results: []interface{}{
+			map[string]string{"@id": "alice"},
+			map[string]string{"@id": "dan"},
+		},
+	},
+	{
+		name: "MinDegree Lookup",
+		data: []quad.Quad{
+			quad.MakeIRI("alice", "likes", "bob", ""),
+			quad.MakeIRI("alice", "likes", "dan", ""),
+			quad.MakeIRI("bob", "likes", "alice", ""),
+			quad.MakeIRI("dan", "likes", "alice", ""),
+			quad.MakeIRI("dan", "likes", "bob", ""),
+		},
+		query: &Intersect{
+			From: &Vertex{Values: []quad.Value{quad.IRI("alice"), quad.IRI("bob"), quad.IRI("dan")}},
+			Steps: []PathStep{
+				&MinDegree{
+					From:     &Vertex{},
+					Property: PropertyPath{PropertyIRIString("likes")},
+					Count:    2,
+				},
+			},
+		},
+		results: []interface{}{
+			map[string]string{"@id": "alice"},
+			map[string]string{"@id": "dan"},
+		},
+	},
+	{
+		name: "MinInDegree",
+		data: []quad.Quad{
+			quad.MakeIRI("alice", "likes", "bob", ""),
+			quad.MakeIRI("dan", "likes", "bob", ""),
+			quad.MakeIRI("eve", "likes", "bob", ""),
+			quad.MakeIRI("alice", "likes", "dan", ""),
+			quad.MakeIRI("bob", "likes", "eve", ""),
+			quad.MakeIRI("dan", "likes", "eve", ""),
+			quad.MakeIRI("eve", "follows", "alice", ""),
+			quad.MakeIRI("bob", "follows", "alice", ""),
+		},
+		query: &MinInDegree{
+			From:     &Vertex{},
+			Property: PropertyPath{PropertyIRIString("likes")},
+			Count:    2,
+		},
+		results: []interface{}{
+			map[string]string{"@id": "bob"},
+			map[string]string{"@id": "eve"},
+		},
+	},
+	{
+		name: "Has Operator",
+		data: []quad.Quad{
+			quad.Make(quad.IRI("alice"), quad.IRI("age"), quad.Int(30), nil),
+			quad.Make(quad.IRI("bob"), quad.IRI("age"), quad.Int(12), nil),
+			quad.Make(quad.IRI("dan"), quad.IRI("age"), quad.Int(18), nil),
+		},
+		query: &Has{
+			From:     &Vertex{},
+			Property: PropertyPath{PropertyIRIString("age")},
+			Operator: "gt",
+			Value:    quad.Int(18),
+		},
+		results: []interface{}{
+			map[string]string{"@id": "alice"},
+		},
+	},
+	{
+		name: "Match",
+		data: []quad.Quad{
+			quad.MakeIRI("alice", "friend", "bob", ""),
+			quad.MakeIRI("carol", "friend", "dan", ""),
+			quad.MakeIRI("eve", "friend", "frank", ""),
+			quad.Make(quad.IRI("bob"), quad.IRI("name"), quad.String("Bob"), nil),
+			quad.Make(quad.IRI("dan"), quad.IRI("name"), quad.String("Dan"), nil),
+			quad.Make(quad.IRI("frank"), quad.IRI("name"), quad.String("Frank"), nil),
+		},
+		query: &Match{
+			From: &Vertex{},
+			GraphPattern: GraphPattern{
+				"friend": map[string]interface{}{"name": "Bob"},
+			},
+		},
+		results: []interface{}{
+			map[string]string{"@id": "alice"},
+		},
+	},
+	{
+		name: "Match Any",
+		data: []quad.Quad{
+			quad.MakeIRI("alice", "friend", "bob", ""),
+			quad.MakeIRI("carol", "friend", "dan", ""),
+			quad.MakeIRI("eve", "friend", "frank", ""),
+			quad.Make(quad.IRI("bob"), quad.IRI("name"), quad.String("Bob"), nil),
+			quad.Make(quad.IRI("dan"), quad.IRI("name"), quad.String("Dan"), nil),
+			quad.Make(quad.IRI("frank"), quad.IRI("name"), quad.String("Frank"), nil),
+		},
+		query: &Match{
+			From: &Vertex{},
+			GraphPattern: GraphPattern{
+				"friend": []interface{}{
+					map[string]interface{}{"name": []interface{}{"Bob", "Dan"}},
+					map[string]interface{}{"@id": "frank"},
+				},
+			},
+		},
+		results: []interface{}{
+			map[string]string{"@id": "alice"},
+			map[string]string{"@id": "carol"},
+			map[string]string{"@id": "eve"},
+		},
+	},
+	{
+		name: "Match Not",
+		data: []quad.Quad{
+			quad.MakeIRI("alice", "likes", "bob", ""),
+			quad.MakeIRI("carol", "likes", "dan", ""),
+			quad.MakeIRI("eve", "likes", "bob", ""),
+			quad.MakeIRI("eve", "likes", "dan", ""),
+		},
+		// entities liking someone, but not bob
+		query: &Match{
+			From: &Vertex{},
+			GraphPattern: GraphPattern{
+				"likes": map[string]interface{}{},
+				"@not":  map[string]interface{}{"likes": map[string]interface{}{"@id": "bob"}},
+			},
+		},
+		results: []interface{}{
+			map[string]string{"@id": "carol"},
+		},
+	},
+	{
+		name: "Match Not Reverse",
+		data: []quad.Quad{
+			quad.MakeIRI("alice", "likes", "bob", ""),
+			quad.MakeIRI("bob", "likes", "alice", ""),
+			quad.MakeIRI("carol", "likes", "dan", ""),
+			quad.MakeIRI("eve", "likes", "carol", ""),
+		},
+		// entities liking someone and liked by no one. Patterns have no variables,
+		// so whether the entities they like like them back can not be expressed.
+		query: &Match{
+			From: &Vertex{},
+			GraphPattern: GraphPattern{
+				"likes": map[string]interface{}{},
+				"@not": map[string]interface{}{
+					"@reverse": map[string]interface{}{"likes": map[string]interface{}{}},
+				},
+			},
+		},
+		results: []interface{}{
+			map[string]string{"@id": "eve"},
+		},
+	},
+	{
+		name: "HasDatatypeProperty",
+		data: []quad.Quad{
+			quad.Make(quad.IRI("alice"), quad.IRI("age"), quad.Int(30), nil),
+			quad.Make(quad.IRI("bob"), quad.IRI("age"), quad.String("thirty"), nil),
+			quad.Make(quad.IRI("dan"), quad.IRI("name"), quad.Int(12), nil),
+		},
+		query: &HasDatatypeProperty{
+			From:     &Vertex{},
+			Property: PropertyPath{PropertyIRIString("age")},
+			Datatype: quad.IRI("xsd:integer"),
+		},
+		results: []interface{}{
+			map[string]string{"@id": "alice"},
+		},
+	},
+	{
+		name: "UpdatedSince",
+		data: []quad.Quad{
+			quad.Make(quad.IRI("alice"), quad.IRI("modifiedAt"), quad.Time(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)), nil),
+			quad.Make(quad.IRI("bob"), quad.IRI("modifiedAt"), quad.Time(time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)), nil),
+		},
+		query: &UpdatedSince{
+			From:     &Vertex{},
+			Property: PropertyPath{PropertyIRIString("modifiedAt")},
+			Since:    quad.Time(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)),
+		},
+		results: []interface{}{
+			map[string]string{"@id": "bob"},
+		},
+	},
+	{
+		name: "Order Case Insensitive",
+		data: []quad.Quad{
+			quad.Make(quad.IRI("alice"), quad.IRI("likes"), quad.String("cherry"), nil),
+			quad.Make(quad.IRI("alice"), quad.IRI("likes"), quad.String("Banana"), nil),
+			quad.Make(quad.IRI("alice"), quad.IRI("likes"), quad.String("apple"), nil),
+		},
+		query: &Order{
+			From: &Visit{
+				From:       &Vertex{Values: []quad.Value{quad.IRI("alice")}},
+				Properties: PropertyPath{PropertyIRIString("likes")},
+			},
+			Collation: "case-insensitive",
+		},
+		results: []interface{}{
+			"apple",
+			"Banana",
+			"cherry",
+		},
+	},
+	{
+		name: "Order Numeric",
+		data: []quad.Quad{
+			quad.Make(quad.IRI("alice"), quad.IRI("score"), quad.Int(10), nil),
+			quad.Make(quad.IRI("bob"), quad.IRI("score"), quad.Int(9), nil),
+			quad.Make(quad.IRI("dan"), quad.IRI("score"), quad.Float(2.5), nil),
+			quad.Make(quad.IRI("eve"), quad.IRI("score"), quad.String("none"), nil),
+		},
+		query: &Order{
+			From: &Visit{
+				From:       &Vertex{Values: []quad.Value{quad.IRI("alice"), quad.IRI("bob"), quad.IRI("dan"), quad.IRI("eve")}},
+				Properties: PropertyPath{PropertyIRIString("score")},
+			},
+			Collation: "numeric",
+		},
+		results: []interface{}{
+			map[string]string{"@value": "2.5E+00", "@type": "xsd:double"},
+			map[string]string{"@value": "9", "@type": "xsd:integer"},
+			map[string]string{"@value": "10", "@type": "xsd:integer"},
+			"none",
+		},
+	},
+	{
+		name: "Optional",
+		data: []quad.Quad{
+			quad.MakeIRI("alice", "likes", "bob", ""),
+			quad.MakeIRI("alice", "name", "Alice", ""),
+			quad.MakeIRI("bob", "name", "Bob", ""),
+		},
+		query: &Select{
+			From: &Optional{
+				From: &Properties{
+					From:  &Vertex{Values: []quad.Value{}},
+					Names: []quad.IRI{quad.IRI("name")},
+				},
+				Step: &Properties{
+					From:  &Placeholder{},
+					Names: []quad.IRI{quad.IRI("likes")},
+				},
+			},
+		},
+		results: []interface{}{
+			map[string]interface{}{
+				"likes": map[string]string{"@id": "bob"},
+				"name":  map[string]string{"@id": "Alice"},
+			},
+			map[string]interface{}{
+				"name": map[string]string{"@id": "Bob"},
+			},
+		},
+	},
+	{
+		name: "Select FillMissing",
+		data: []quad.Quad{
+			quad.MakeIRI("alice", "likes", "bob", ""),
+			quad.MakeIRI("alice", "name", "Alice", ""),
+			quad.MakeIRI("bob", "name", "Bob", ""),
+		},
+		query: &Select{
+			Tags: []string{"name", "likes"},
+			From: &Optional{
+				From: &Properties{
+					From:  &Vertex{Values: []quad.Value{}},
+					Names: []quad.IRI{quad.IRI("name")},
+				},
+				Step: &Properties{
+					From:  &Placeholder{},
+					Names: []quad.IRI{quad.IRI("likes")},
+				},
+			},
+			FillMissing: true,
+		},
+		results: []interface{}{
+			map[string]interface{}{
+				"likes": map[string]string{"@id": "bob"},
+				"name":  map[string]string{"@id": "Alice"},
+			},
+			map[string]interface{}{
+				"likes": nil,
+				"name":  map[string]string{"@id": "Bob"},
+			},
+		},
+	},
+	{
+		name: "Where",
+		data: []quad.Quad{
+			quad.MakeIRI("alice", "likes", "bob", ""),
+			quad.MakeIRI("alice", "name", "Alice", ""),
+			quad.MakeIRI("bob", "name", "Bob", ""),
+		},
+		query: &Select{
+			From: &As{
+				From: &Where{
+					From: &Vertex{},
+					Steps: []PathStep{
+						&As{
+							From: &Visit{
+								From: &Visit{
+									From:       &Placeholder{},
+									Properties: PropertyPath{&Vertex{Values: []quad.Value{quad.IRI("likes")}}},
+								},
+								Properties: PropertyPath{&Vertex{Values: []quad.Value{quad.IRI("name")}}},
+							},
+							Name: "likesName",
+						},
+						&As{
+							From: &Visit{
+								From:       &Placeholder{},
+								Properties: PropertyPath{&Vertex{Values: []quad.Value{quad.IRI("name")}}},
+							},
+							Name: "name",
+						},
+					},
+				},
+				Name: "person",
+			},
+		},
+		results: []interface{}{
+			map[string]interface{}{
+				"person":    map[string]string{"@id": "alice"},
+				"name":      map[string]string{"@id": "Alice"},
+				"likesName": map[string]string{"@id": "Bob"},
+			},
+		},
+	},
+	{
+		name: "Documents",
+		data: []quad.Quad{
+			quad.MakeIRI("alice", "likes", "bob", ""),
+			quad.MakeIRI("alice", "name", "Alice", ""),
+			quad.MakeIRI("bob", "name", "Bob", ""),
+			quad.MakeIRI("bob", "likes", "alice", ""),
+		},
+		query: &Documents{
+			From: &Properties{
+				From:  &Vertex{Values: []quad.Value{}},
+				Names: []quad.IRI{quad.IRI("name"), quad.IRI("likes")},
+			},
+		},
+		results: []interface{}{
+			map[string]interface{}{
+				"@id":   "alice",
+				"name":  []interface{}{map[string]string{"@id": "Alice"}},
+				"likes": []interface{}{map[string]string{"@id": "bob"}},
+			},
+			map[string]interface{}{
+				"@id":   "bob",
+				"name":  []interface{}{map[string]string{"@id": "Bob"}},
+				"likes": []interface{}{map[string]string{"@id": "alice"}},
+			},
+		},
+	},
+	{
+		name: "Documents with several values",
+		data: []quad.Quad{
+			quad.MakeIRI("alice", "likes", "dan", ""),
+			quad.MakeIRI("alice", "likes", "bob", ""),
+			quad.MakeIRI("alice", "name", "Alice", ""),
+			quad.MakeIRI("alice", "name", "Al", ""),
+			quad.MakeIRI("carol", "likes", "dan", ""),
+		},
+		query: &Documents{
+			From: &Properties{
+				From:  &Vertex{Values: []quad.Value{quad.IRI("alice"), quad.IRI("carol")}},
+				Names: []quad.IRI{quad.IRI("name"), quad.IRI("likes")},
+			},
+		},
+		results: []interface{}{
+			map[string]interface{}{
+				"@id": "alice",
+				"name": []interface{}{
+					map[string]string{"@id": "Al"},
+					map[string]string{"@id": "Alice"},
+				},
+				"likes": []interface{}{
+					map[string]string{"@id": "bob"},
+					map[string]string{"@id": "dan"},
+				},
+			},
+		},
+	},
+	{
+		name: "Documents with aliases",
+		data: []quad.Quad{
+			quad.MakeIRI("alice", "likes", "bob", ""),
+			quad.MakeIRI("alice", "name", "Alice", ""),
+		},
+		query: &Documents{
+			From: &Properties{
+				From:    &Vertex{Values: []quad.Value{quad.IRI("alice")}},
+				Names:   []quad.IRI{quad.IRI("name"), quad.IRI("likes")},
+				Aliases: map[string]string{"likes": "friend"},
+			},
+		},
+		results: []interface{}{
+			map[string]interface{}{
+				"@id":    "alice",
+				"name":   []interface{}{map[string]string{"@id": "Alice"}},
+				"friend": []interface{}{map[string]string{"@id": "bob"}},
+			},
+		},
+	},
+	{
+		name: "Documents with context",
+		data: []quad.Quad{
+			quad.MakeIRI("http://example.org/alice", "http://example.org/likes", "http://example.org/bob", ""),
+			quad.MakeIRI("http://example.org/alice", "http://example.org/name", "Alice", ""),
+		},
+		query: &Documents{
+			From: &Properties{
+				From:  &Vertex{Values: []quad.Value{quad.IRI("http://example.org/alice")}},
+				Names: []quad.IRI{quad.IRI("http://example.org/name"), quad.IRI("http://example.org/likes")},
+			},
+			Context: map[string]string{"ex": "http://example.org/"},
+		},
+		results: []interface{}{
+			map[string]interface{}{
+				"@context": map[string]string{"ex": "http://example.org/"},
+				"@id":      "ex:alice",
+				"ex:name":  []interface{}{map[string]string{"@id": "Alice"}},
+				"ex:likes": []interface{}{map[string]string{"@id": "ex:bob"}},
+			},
+		},
+	},
+	{
+		name: "Frame",
+		data: []quad.Quad{
+			quad.MakeIRI("alice", "likes", "bob", ""),
+			quad.MakeIRI("alice", "name", "Alice", ""),
+			quad.MakeIRI("bob", "name", "Bob", ""),
+		},
+		query: &Frame{
+			From: &Properties{
+				From:  &Vertex{Values: []quad.Value{quad.IRI("alice")}},
+				Names: []quad.IRI{quad.IRI("name"), quad.IRI("likes")},
+			},
+			Frame: map[string]interface{}{
+				"likes": map[string]interface{}{},
+			},
+		},
+		results: []interface{}{
+			map[string]interface{}{
+				"@id":  "alice",
+				"name": []interface{}{map[string]string{"@id": "Alice"}},
+				"likes": []interface{}{
+					map[string]interface{}{
+						"@id":  "bob",
+						"name": []interface{}{map[string]string{"@id": "Bob"}},
+					},
+				},
+			},
+		},
+	},
+	{
+		name: "Frame explicit",
+		data: []quad.Quad{
+			quad.MakeIRI("alice", "likes", "bob", ""),
+			quad.MakeIRI("alice", "name", "Alice", ""),
+			quad.MakeIRI("bob", "name", "Bob", ""),
+			quad.MakeIRI("bob", "likes", "alice", ""),
+		},
+		query: &Frame{
+			From: &Properties{
+				From:  &Vertex{Values: []quad.Value{quad.IRI("alice")}},
+				Names: []quad.IRI{quad.IRI("name"), quad.IRI("likes")},
+			},
+			Frame: map[string]interface{}{
+				"@explicit": true,
+				"likes": map[string]interface{}{
+					"@explicit": true,
+					"name":      map[string]interface{}{},
+				},
+			},
+		},
+		results: []interface{}{
+			map[string]interface{}{
+				"@id": "alice",
+				"likes": []interface{}{
+					map[string]interface{}{
+						"@id":  "bob",
+						"name": []interface{}{map[string]interface{}{"@id": "Bob"}},
+					},
+				},
+			},
+		},
+	},
+	{
+		name: "PropertyValues",
+		data: []quad.Quad{
+			quad.MakeIRI("alice", "likes", "bob", ""),
+			quad.Make(quad.IRI("alice"), quad.IRI("name"), quad.String("Alice"), nil),
+			quad.Make(quad.IRI("bob"), quad.IRI("name"), quad.String("Bob"), nil),
+		},
+		query: &PropertyValues{
+			From: &Vertex{Values: []quad.Value{quad.IRI("alice")}},
+		},
+		results: []interface{}{
+			map[string]interface{}{
+				"@id":   "alice",
+				"likes": []interface{}{map[string]string{"@id": "bob"}},
+				"name":  []interface{}{"Alice"},
+			},
+		},
+	},
+	{
+		name: "Describe",
+		data: []quad.Quad{
+			quad.MakeIRI("alice", "likes", "bob", ""),
+			quad.MakeIRI("carol", "likes", "alice", ""),
+			quad.Make(quad.IRI("alice"), quad.IRI("name"), quad.String("Alice"), nil),
+		},
+		query: &Describe{
+			From: &Vertex{Values: []quad.Value{quad.IRI("alice")}},
+		},
+		results: []interface{}{
+			map[string]interface{}{
+				"@id":   "alice",
+				"likes": []interface{}{map[string]string{"@id": "bob"}},
+				"name":  []interface{}{"Alice"},
+				"@reverse": map[string]interface{}{
+					"likes": []interface{}{map[string]string{"@id": "carol"}},
+				},
+			},
+		},
+	},
+	{
+		name: "Adjacency",
+		data: singleQuadData,
+		query: &Adjacency{
+			From: &Vertex{Values: []quad.Value{quad.IRI("alice"), quad.IRI("bob")}},
+		},
+		results: []interface{}{
+			map[string]interface{}{
+				"@id": "alice",
+				"out": map[string]interface{}{
+					"likes": []interface{}{map[string]string{"@id": "bob"}},
+				},
+				"in": map[string]interface{}{},
+			},
+			map[string]interface{}{
+				"@id": "bob",
+				"out": map[string]interface{}{},
+				"in": map[string]interface{}{
+					"likes": []interface{}{map[string]string{"@id": "alice"}},
+				},
+			},
+		},
+	},
+	{
+		name: "Centrality",
+		data: []quad.Quad{
+			quad.MakeIRI("alice", "follows", "bob", ""),
+			quad.MakeIRI("bob", "follows", "carol", ""),
+			quad.MakeIRI("dan", "follows", "bob", ""),
+			quad.MakeIRI("bob", "name", "Bob", ""),
+		},
+		query: &Centrality{
+			From: &Vertex{Values: []quad.Value{
+				quad.IRI("alice"),
+				quad.IRI("bob"),
+				quad.IRI("carol"),
+			}},
+			Metric: "degree",
+			Via:    PropertyPath{PropertyIRIString("follows")},
+		},
+		results: []interface{}{
+			map[string]interface{}{
+				"@id":   "alice",
+				"score": map[string]string{"@value": "1", "@type": "xsd:integer"},
+			},
+			map[string]interface{}{
+				"@id":   "bob",
+				"score": map[string]string{"@value": "3", "@type": "xsd:integer"},
+			},
+			map[string]interface{}{
+				"@id":   "carol",
+				"score": map[string]string{"@value": "1", "@type": "xsd:integer"},
+			},
+		},
+	},
+	{
+		name: "Documents with reverse",
+		data: []quad.Quad{
+			quad.MakeIRI("alice", "likes", "bob", ""),
+			quad.MakeIRI("alice", "name", "Alice", ""),
+			quad.MakeIRI("bob", "name", "Bob", ""),
+		},
+		query: &Documents{
+			From: &Properties{
+				From:  &Vertex{Values: []quad.Value{quad.IRI("alice"), quad.IRI("bob")}},
+				Names: []quad.IRI{quad.IRI("name")},
+			},
+			ReverseNames: []quad.IRI{quad.IRI("likes")},
+		},
+		results: []interface{}{
+			map[string]interface{}{
+				"@id":  "alice",
+				"name": []interface{}{map[string]string{"@id": "Alice"}},
+			},
+			map[string]interface{}{
+				"@id":  "bob",
+				"name": []interface{}{map[string]string{"@id": "Bob"}},
+				"@reverse": map[string]interface{}{
+					"likes": []interface{}{map[string]string{"@id": "alice"}},
+				},
+			},
+		},
+	},
+	{
+		name: "Documents with several reverse",
+		data: []quad.Quad{
+			quad.MakeIRI("alice", "likes", "bob", ""),
+			quad.MakeIRI("dan", "likes", "bob", ""),
+			quad.MakeIRI("alice", "name", "Alice", ""),
+			quad.MakeIRI("bob", "name", "Bob", ""),
+		},
+		query: &Documents{
+			From: &Properties{
+				From:  &Vertex{Values: []quad.Value{quad.IRI("bob")}},
+				Names: []quad.IRI{quad.IRI("name")},
+			},
+			ReverseNames: []quad.IRI{quad.IRI("likes")},
+		},
+		results: []interface{}{
+			map[string]interface{}{
+				"@id":  "bob",
+				"name": []interface{}{map[string]string{"@id": "Bob"}},
+				"@reverse": map[string]interface{}{
+					"likes": []interface{}{
+						map[string]string{"@id": "alice"},
+						map[string]string{"@id": "dan"},
+					},
+				},
+			},
+		},
+	},
+	{
+		name: "Documents with reverse batched",
+		data: []quad.Quad{
+			quad.MakeIRI("alice", "likes", "bob", ""),
+			quad.MakeIRI("dan", "likes", "bob", ""),
+			quad.MakeIRI("alice", "name", "Alice", ""),
+			quad.MakeIRI("bob", "name", "Bob", ""),
+		},
+		query: &Documents{
+			From: &Properties{
+				From:  &Vertex{Values: []quad.Value{quad.IRI("alice"), quad.IRI("bob")}},
+				Names: []quad.IRI{quad.IRI("name")},
+			},
+			ReverseNames: []quad.IRI{quad.IRI("likes")},
+			BatchSize:    10,
+		},
+		results: []interface{}{
+			map[string]interface{}{
+				"@id":  "alice",
+				"name": []interface{}{map[string]string{"@id": "Alice"}},
+			},
+			map[string]interface{}{
+				"@id":  "bob",
+				"name": []interface{}{map[string]string{"@id": "Bob"}},
+				"@reverse": map[string]interface{}{
+					"likes": []interface{}{
+						map[string]string{"@id": "alice"},
+						map[string]string{"@id": "dan"},
+					},
+				},
+			},
+		},
+	},
+	{
+		name: "Documents skolemized",
+		data: []quad.Quad{
+			quad.Make(quad.IRI("alice"), quad.IRI("knows"), quad.BNode("b1"), nil),
+			quad.Make(quad.BNode("b1"), quad.IRI("knows"), quad.IRI("bob"), nil),
+		},
+		query: &Documents{
+			From: &Properties{
+				From:  &Vertex{Values: []quad.Value{quad.IRI("alice"), quad.BNode("b1")}},
+				Names: []quad.IRI{quad.IRI("knows")},
+			},
+			Skolemize: true,
+			BaseIRI:   "http://example.org/",
+		},
+		results: []interface{}{
+			map[string]interface{}{
+				"@id":   "alice",
+				"knows": []interface{}{map[string]string{"@id": "http://example.org/.well-known/genid/b1"}},
+			},
+			map[string]interface{}{
+				"@id":   "http://example.org/.well-known/genid/b1",
+				"knows": []interface{}{map[string]string{"@id": "bob"}},
+			},
+		},
+	},
+	{
+		name: "StringJoin",
+		data: []quad.Quad{
+			quad.Make(quad.IRI("alice"), quad.IRI("tag"), quad.String("a"), nil),
+			quad.Make(quad.IRI("alice"), quad.IRI("tag"), quad.String("b"), nil),
+		},
+		query: &StringJoin{
+			From: &Order{
+				From: &Visit{
+					From:       &Vertex{Values: []quad.Value{quad.IRI("alice")}},
+					Properties: PropertyPath{PropertyIRIString("tag")},
+				},
+			},
+			Separator: ", ",
+		},
+		results: []interface{}{
+			"a, b",
+		},
+	},
+	{
+		name: "StringJoin lexical forms",
+		data: []quad.Quad{
+			quad.Make(quad.IRI("alice"), quad.IRI("likes"), quad.IRI("bob"), nil),
+			quad.Make(quad.IRI("alice"), quad.IRI("age"), quad.Int(30), nil),
+		},
+		query: &StringJoin{
+			From: &Visit{
+				From:       &Vertex{Values: []quad.Value{quad.IRI("alice")}},
+				Properties: PropertyPath{PropertyIRIStrings{"likes", "age"}},
+			},
+			Separator: "|",
+		},
+		results: []interface{}{
+			"bob|30",
+		},
+	},
+	{
+		name: "Average",
+		data: []quad.Quad{
+			quad.Make(quad.IRI("a"), quad.IRI("value"), quad.Int(1), nil),
+			quad.Make(quad.IRI("b"), quad.IRI("value"), quad.Int(2), nil),
+			quad.Make(quad.IRI("c"), quad.IRI("value"), quad.Int(3), nil),
+		},
+		query: &Average{
+			From: &Visit{
+				From:       &Vertex{},
+				Properties: PropertyPath{PropertyIRIString("value")},
+			},
+		},
+		results: []interface{}{
+			map[string]string{"@value": "2E+00", "@type": "xsd:double"},
+		},
+	},
+	{
+		name: "Average Mixed",
+		data: []quad.Quad{
+			quad.Make(quad.IRI("a"), quad.IRI("value"), quad.Int(1), nil),
+			quad.Make(quad.IRI("b"), quad.IRI("value"), quad.Float(2.5), nil),
+		},
+		query: &Average{
+			From: &Visit{
+				From:       &Vertex{},
+				Properties: PropertyPath{PropertyIRIString("value")},
+			},
+		},
+		results: []interface{}{
+			map[string]string{"@value": "1.75E+00", "@type": "xsd:double"},
+		},
+	},
+	{
+		name: "Average Empty",
+		data: singleQuadData,
+		query: &Average{
+			From: &Vertex{},
+		},
+		results: nil,
+	},
+	{
+		name: "GroupCount",
+		data: singleQuadData,
+		query: &GroupCount{
+			From:     &Vertex{},
+			Property: PropertyPath{PropertyIRIString("likes")},
+		},
+		results: []interface{}{
+			map[string]interface{}{"@id": "bob", "count": int64(1)},
+		},
+	},
+	{
+		name: "GroupCount Literals",
+		data: []quad.Quad{
+			quad.Make(quad.IRI("alice"), quad.IRI("age"), quad.Int(30), nil),
+			quad.Make(quad.IRI("bob"), quad.IRI("age"), quad.Int(30), nil),
+			quad.Make(quad.IRI("dan"), quad.IRI("age"), quad.String("30"), nil),
+			quad.Make(quad.IRI("eve"), quad.IRI("age"), quad.BNode("x"), nil),
+		},
+		query: &GroupCount{
+			From:     &Vertex{},
+			Property: PropertyPath{PropertyIRIString("age")},
+		},
+		results: []interface{}{
+			map[string]interface{}{"@id": map[string]string{"@value": "30", "@type": "xsd:integer"}, "count": int64(2)},
+			map[string]interface{}{"@id": "30", "count": int64(1)},
+			map[string]interface{}{"@id": "_:x", "count": int64(1)},
+		},
+	},
+	{
+		name: "OrderBy",
+		data: []quad.Quad{
+			quad.Make(quad.IRI("alice"), quad.IRI("age"), quad.Int(30), nil),
+			quad.Make(quad.IRI("bob"), quad.IRI("age"), quad.Int(4), nil),
+			quad.Make(quad.IRI("dan"), quad.IRI("age"), quad.Float(12.5), nil),
+			quad.Make(quad.IRI("eve"), quad.IRI("name"), quad.String("Eve"), nil),
+		},
+		query: &OrderBy{
+			From: &Has{
+				From:     &Vertex{},
+				Property: PropertyPath{PropertyIRIs{quad.IRI("age"), quad.IRI("name")}},
+			},
+			Property: PropertyPath{PropertyIRIString("age")},
+		},
+		results: []interface{}{
+			map[string]string{"@id": "bob"},
+			map[string]string{"@id": "dan"},
+			map[string]string{"@id": "alice"},
+			map[string]string{"@id": "eve"},
+		},
+	},
+	{
+		name: "OrderBy Descending",
+		data: []quad.Quad{
+			quad.Make(quad.IRI("alice"), quad.IRI("age"), quad.Int(30), nil),
+			quad.Make(quad.IRI("bob"), quad.IRI("age"), quad.Int(4), nil),
+			quad.Make(quad.IRI("dan"), quad.IRI("age"), quad.Float(12.5), nil),
+			quad.Make(quad.IRI("eve"), quad.IRI("name"), quad.String("Eve"), nil),
+		},
+		query: &OrderBy{
+			From: &Has{
+				From:     &Vertex{},
+				Property: PropertyPath{PropertyIRIs{quad.IRI("age"), quad.IRI("name")}},
+			},
+			Property:   PropertyPath{PropertyIRIString("age")},
+			Descending: true,
+		},
+		results: []interface{}{
+			map[string]string{"@id": "alice"},
+			map[string]string{"@id": "dan"},
+			map[string]string{"@id": "bob"},
+			map[string]string{"@id": "eve"},
+		},
+	},
+	{
+		name: "UniqueBy",
+		data: []quad.Quad{
+			quad.Make(quad.IRI("alice"), quad.IRI("name"), quad.String("Alice"), nil),
+			quad.Make(quad.IRI("alice2"), quad.IRI("name"), quad.String("Alice"), nil),
+			quad.Make(quad.IRI("bob"), quad.IRI("name"), quad.String("Bob"), nil),
+		},
+		query: &UniqueBy{
+			From: &Entities{Identifiers: []EntityIdentifier{
+				EntityIdentifierString("alice"),
+				EntityIdentifierString("alice2"),
+				EntityIdentifierString("bob"),
+			}},
+			Property: PropertyPath{PropertyIRIString("name")},
+		},
+		results: []interface{}{
+			map[string]string{"@id": "alice"},
+			map[string]string{"@id": "bob"},
+		},
+	},
+	{
+		name: "LimitPerGroup",
+		data: []quad.Quad{
+			quad.MakeIRI("apple", "category", "fruit", ""),
+			quad.MakeIRI("banana", "category", "fruit", ""),
+			quad.MakeIRI("carrot", "category", "vegetable", ""),
+			quad.MakeIRI("leek", "category", "vegetable", ""),
+		},
+		query: &LimitPerGroup{
+			From: &Entities{Identifiers: []EntityIdentifier{
+				EntityIdentifierString("apple"),
+				EntityIdentifierString("banana"),
+				EntityIdentifierString("carrot"),
+				EntityIdentifierString("leek"),
+			}},
+			Key:   PropertyPath{PropertyIRIString("category")},
+			Count: 1,
+		},
+		results: []interface{}{
+			map[string]string{"@id": "apple"},
+			map[string]string{"@id": "carrot"},
+		},
+	},
+	{
+		name: "Trace",
+		data: []quad.Quad{
+			quad.MakeIRI("alice", "likes", "bob", ""),
+			quad.MakeIRI("bob", "likes", "dan", ""),
+		},
+		query: &Trace{
+			From: &Visit{
+				From: &Visit{
+					From:       &Vertex{Values: []quad.Value{quad.IRI("alice")}},
+					Properties: PropertyPath{PropertyIRIString("likes")},
+				},
+				Properties: PropertyPath{PropertyIRIString("likes")},
+			},
+		},
+		results: []interface{}{
+			map[string]interface{}{
+				"@id": "dan",
+				"@path": []interface{}{
+					map[string]string{"@id": "alice"},
+					map[string]string{"@id": "bob"},
+					map[string]string{"@id": "dan"},
+				},
+			},
+		},
+	},
+	{
+		name: "Trace Self Loop",
+		data: []quad.Quad{
+			quad.MakeIRI("alice", "likes", "alice", ""),
+			quad.MakeIRI("alice", "likes", "bob", ""),
+		},
+		query: &Trace{
+			From: &Is{
+				From: &Visit{
+					From: &Has{
+						From:     &Vertex{Values: []quad.Value{quad.IRI("alice")}},
+						Property: PropertyPath{PropertyIRIString("likes")},
+					},
+					Properties: PropertyPath{PropertyIRIString("likes")},
+				},
+				Values: []quad.Value{quad.IRI("alice")},
+			},
+		},
+		results: []interface{}{
+			map[string]interface{}{
+				"@id": "alice",
+				"@path": []interface{}{
+					map[string]string{"@id": "alice"},
+					map[string]string{"@id": "alice"},
+				},
+			},
+		},
+	},
+	{
+		name: "FollowRecursive",
+		data: []quad.Quad{
+			quad.MakeIRI("alice", "parent", "bob", ""),
+			quad.MakeIRI("bob", "parent", "carol", ""),
+			quad.MakeIRI("carol", "parent", "dan", ""),
+		},
+		query: &FollowRecursive{
+			From:     &Vertex{Values: []quad.Value{quad.IRI("alice")}},
+			Property: PropertyPath{PropertyIRIString("parent")},
+		},
+		results: []interface{}{
+			map[string]string{"@id": "bob"},
+			map[string]string{"@id": "carol"},
+			map[string]string{"@id": "dan"},
+		},
+	},
+	{
+		name: "FollowRecursive MaxDepth",
+		data: []quad.Quad{
+			quad.MakeIRI("alice", "parent", "bob", ""),
+			quad.MakeIRI("bob", "parent", "carol", ""),
+			quad.MakeIRI("carol", "parent", "dan", ""),
+		},
+		query: &FollowRecursive{
+			From:     &Vertex{Values: []quad.Value{quad.IRI("alice")}},
+			Property: PropertyPath{PropertyIRIString("parent")},
+			MaxDepth: 2,
+		},
+		results: []interface{}{
+			map[string]string{"@id": "bob"},
+			map[string]string{"@id": "carol"},
+		},
+	},
+	{
+		name: "FollowRecursive Cycle",
+		data: []quad.Quad{
+			quad.MakeIRI("alice", "parent", "bob", ""),
+			quad.MakeIRI("bob", "parent", "alice", ""),
+		},
+		query: &FollowRecursive{
+			From:     &Vertex{Values: []quad.Value{quad.IRI("alice")}},
+			Property: PropertyPath{PropertyIRIString("parent")},
+			MaxDepth: -1,
+		},
+		results: []interface{}{
+			map[string]string{"@id": "bob"},
+			map[string]string{"@id": "alice"},
+		},
+	},
+	{
+		name: "Save",
+		data: singleQuadData,
+		query: &Select{
+			From: &Save{
+				From: &As{
+					From: &Vertex{},
+					Name: "person",
+				},
+				Property: PropertyPath{PropertyIRIString("likes")},
+				Tag:      "liked",
+			},
+		},
+		results: []interface{}{
+			map[string]interface{}{
+				"person": map[string]string{"@id": "alice"},
+				"liked":  map[string]string{"@id": "bob"},
+			},
+		},
+	},
+	{
+		name: "SaveReverse",
+		data: singleQuadData,
+		query: &Select{
+			From: &SaveReverse{
+				From: &As{
+					From: &Vertex{},
+					Name: "person",
+				},
+				Property: PropertyPath{PropertyIRIString("likes")},
+				Tag:      "likedBy",
+			},
+		},
+		results: []interface{}{
+			map[string]interface{}{
+				"person":  map[string]string{"@id": "bob"},
+				"likedBy": map[string]string{"@id": "alice"},
+			},
+		},
+	},
+	{
+		name: "SaveOptional",
+		data: []quad.Quad{
+			quad.MakeIRI("alice", "likes", "bob", ""),
+			quad.MakeIRI("alice", "name", "Alice", ""),
+			quad.MakeIRI("bob", "name", "Bob", ""),
+		},
+		query: &Select{
+			From: &SaveOptional{
+				From: &Save{
+					From: &As{
+						From: &Vertex{},
+						Name: "person",
+					},
+					Property: PropertyPath{PropertyIRIString("name")},
+					Tag:      "name",
+				},
+				Property: PropertyPath{PropertyIRIString("likes")},
+				Tag:      "liked",
+			},
+		},
+		results: []interface{}{
+			map[string]interface{}{
+				"person": map[string]string{"@id": "alice"},
+				"name":   map[string]string{"@id": "Alice"},
+				"liked":  map[string]string{"@id": "bob"},
+			},
+			map[string]interface{}{
+				"person": map[string]string{"@id": "bob"},
+				"name":   map[string]string{"@id": "Bob"},
+			},
+		},
+	},
+	{
+		name: "Labels",
+		data: []quad.Quad{
+			quad.MakeIRI("alice", "likes", "bob", "social"),
+			quad.MakeIRI("bob", "name", "Bob", "profiles"),
+		},
+		query: &Labels{
+			From: &Vertex{Values: []quad.Value{quad.IRI("alice")}},
+		},
+		results: []interface{}{
+			map[string]string{"@id": "social"},
+		},
+	},
+	{
+		name: "Labels Both Directions",
+		data: []quad.Quad{
+			quad.MakeIRI("alice", "likes", "bob", "social"),
+			quad.MakeIRI("bob", "name", "Bob", "profiles"),
+		},
+		query: &Order{
+			From: &Unique{
+				From: &Labels{
+					From: &Vertex{Values: []quad.Value{quad.IRI("bob")}},
+				},
+			},
+		},
+		results: []interface{}{
+			map[string]string{"@id": "profiles"},
+			map[string]string{"@id": "social"},
+		},
+	},
+	{
+		name: "LabelContext",
+		data: []quad.Quad{
+			quad.MakeIRI("alice", "likes", "bob", "work"),
+			quad.MakeIRI("alice", "likes", "dan", "home"),
+		},
+		query: &Visit{
+			From: &LabelContext{
+				From:   &Vertex{Values: []quad.Value{quad.IRI("alice")}},
+				Labels: []quad.Value{quad.IRI("work")},
+			},
+			Properties: PropertyPath{PropertyIRIString("likes")},
+		},
+		results: []interface{}{
+			map[string]string{"@id": "bob"},
+		},
+	},
+	{
+		name: "LabelContext Cleared",
+		data: []quad.Quad{
+			quad.MakeIRI("alice", "likes", "bob", "work"),
+			quad.MakeIRI("alice", "likes", "dan", "home"),
+		},
+		query: &Visit{
+			From: &LabelContext{
+				From: &LabelContext{
+					From:   &Vertex{Values: []quad.Value{quad.IRI("alice")}},
+					Labels: []quad.Value{quad.IRI("work")},
+				},
+				Labels: []quad.Value{},
+			},
+			Properties: PropertyPath{PropertyIRIString("likes")},
+		},
+		results: []interface{}{
+			map[string]string{"@id": "bob"},
+			map[string]string{"@id": "dan"},
+		},
+	},
+	{
+		name: "Within",
+		data: singleQuadData,
+		query: &Within{
+			Is: Is{
+				From:   &Vertex{},
+				Values: []quad.Value{quad.IRI("bob")},
+			},
+		},
+		results: []interface{}{
+			map[string]string{"@id": "bob"},
+		},
+	},
+	{
+		name: "Within Typed Literals",
+		data: []quad.Quad{
+			quad.Make(quad.IRI("alice"), quad.IRI("age"), quad.Int(30), nil),
+			quad.Make(quad.IRI("bob"), quad.IRI("age"), quad.String("30"), nil),
+		},
+		query: &Within{
+			Is: Is{
+				From: &Visit{
+					From:       &Vertex{},
+					Properties: PropertyPath{PropertyIRIString("age")},
+				},
+				Values: []quad.Value{quad.Int(30)},
+			},
+		},
+		results: []interface{}{
+			map[string]string{"@value": "30", "@type": "xsd:integer"},
+		},
+	},
+	{
+		name: "NotWithin",
+		data: singleQuadData,
+		query: &NotWithin{
+			From:   &Vertex{},
+			Values: []quad.Value{quad.IRI("likes")},
+		},
+		results: []interface{}{
+			map[string]string{"@id": "alice"},
+			map[string]string{"@id": "bob"},
+		},
+	},
+	{
+		name: "Equals",
+		data: []quad.Quad{
+			{Subject: quad.IRI("alice"), Predicate: quad.IRI("age"), Object: quad.Int(0), Label: nil},
+			{Subject: quad.IRI("alice"), Predicate: quad.IRI("age"), Object: quad.Int(1), Label: nil},
+			{Subject: quad.IRI("alice"), Predicate: quad.IRI("age"), Object: quad.String("1"), Label: nil},
+		},
+		query: &Equals{
+			From: &Visit{
+				From:       &Vertex{Values: []quad.Value{quad.IRI("alice")}},
+				Properties: PropertyPath{PropertyIRIString("age")},
+			},
+			Value: quad.Int(1),
+		},
+		results: []interface{}{
+			map[string]string{"@value": "1", "@type": "xsd:integer"},
+		},
+	},
+	{
+		name: "NotEquals",
+		data: []quad.Quad{
+			{Subject: quad.IRI("alice"), Predicate: quad.IRI("age"), Object: quad.Int(0), Label: nil},
+			{Subject: quad.IRI("alice"), Predicate: quad.IRI("age"), Object: quad.Int(1), Label: nil},
+			{Subject: quad.IRI("alice"), Predicate: quad.IRI("age"), Object: quad.String("1"), Label: nil},
+		},
+		query: &NotEquals{
+			From: &Visit{
+				From:       &Vertex{Values: []quad.Value{quad.IRI("alice")}},
+				Properties: PropertyPath{PropertyIRIString("age")},
+			},
+			Value: quad.Int(1),
+		},
+		results: []interface{}{
+			map[string]string{"@value": "0", "@type": "xsd:integer"},
+			"1",
+		},
+	},
+	{
+		name: "Between",
+		data: []quad.Quad{
+			{Subject: quad.IRI("alice"), Predicate: quad.IRI("value"), Object: quad.Int(0), Label: nil},
+			{Subject: quad.IRI("alice"), Predicate: quad.IRI("value"), Object: quad.Int(1), Label: nil},
+			{Subject: quad.IRI("alice"), Predicate: quad.IRI("value"), Object: quad.Int(2), Label: nil},
+			{Subject: quad.IRI("alice"), Predicate: quad.IRI("value"), Object: quad.Int(3), Label: nil},
+			{Subject: quad.IRI("alice"), Predicate: quad.IRI("value"), Object: quad.Int(4), Label: nil},
+		},
+		query: &Between{
+			From: &Vertex{Values: []quad.Value{}},
+			Min:  quad.Int(1),
+			Max:  quad.Int(3),
+		},
+		results: []interface{}{
+			map[string]string{"@value": "1", "@type": "xsd:integer"},
+			map[string]string{"@value": "2", "@type": "xsd:integer"},
+			map[string]string{"@value": "3", "@type": "xsd:integer"},
+		},
+	},
+	{
+		name: "IsIRI",
+		data: []quad.Quad{
+			{Subject: quad.IRI("alice"), Predicate: quad.IRI("name"), Object: quad.String("Alice"), Label: nil},
+			{Subject: quad.IRI("alice"), Predicate: quad.IRI("knows"), Object: quad.BNode("bob"), Label: nil},
+		},
+		query: &IsIRI{
+			From: &Vertex{Values: []quad.Value{}},
+		},
+		results: []interface{}{
+			map[string]string{"@id": "alice"},
+			map[string]string{"@id": "name"},
+			map[string]string{"@id": "knows"},
+		},
+	},
+	{
+		name: "IsLiteral",
+		data: []quad.Quad{
+			{Subject: quad.IRI("alice"), Predicate: quad.IRI("name"), Object: quad.String("Alice"), Label: nil},
+			{Subject: quad.IRI("alice"), Predicate: quad.IRI("knows"), Object: quad.BNode("bob"), Label: nil},
+		},
+		query: &IsLiteral{
+			From: &Vertex{Values: []quad.Value{}},
+		},
+		results: []interface{}{
+			"Alice",
+		},
+	},
+	{
+		name: "IsBlankNode",
+		data: []quad.Quad{
+			{Subject: quad.IRI("alice"), Predicate: quad.IRI("name"), Object: quad.String("Alice"), Label: nil},
+			{Subject: quad.IRI("alice"), Predicate: quad.IRI("knows"), Object: quad.BNode("bob"), Label: nil},
+		},
+		query: &IsBlankNode{
+			From: &Vertex{Values: []quad.Value{}},
+		},
+		results: []interface{}{
+			map[string]string{"@id": "_:bob"},
+		},
+	},
+	{
+		name: "HasDatatype",
+		data: []quad.Quad{
+			{Subject: quad.IRI("alice"), Predicate: quad.IRI("age"), Object: quad.Int(30), Label: nil},
+			{Subject: quad.IRI("alice"), Predicate: quad.IRI("name"), Object: quad.String("Alice"), Label: nil},
+			{Subject: quad.IRI("alice"), Predicate: quad.IRI("height"), Object: quad.TypedString{Value: "170", Type: "xsd:integer"}, Label: nil},
+		},
+		query: &HasDatatype{
+			From:     &Vertex{Values: []quad.Value{}},
+			Datatype: quad.IRI("http://www.w3.org/2001/XMLSchema#integer"),
+		},
+		results: []interface{}{
+			map[string]string{"@value": "30", "@type": "xsd:integer"},
+			map[string]string{"@value": "170", "@type": "xsd:integer"},
+		},
+	},
+	{
+		name: "HasLanguage",
+		data: []quad.Quad{
+			{Subject: quad.IRI("cat"), Predicate: quad.IRI("label"), Object: quad.LangString{Value: "cat", Lang: "en"}, Label: nil},
+			{Subject: quad.IRI("cat"), Predicate: quad.IRI("label"), Object: quad.LangString{Value: "chat", Lang: "fr"}, Label: nil},
+			{Subject: quad.IRI("cat"), Predicate: quad.IRI("label"), Object: quad.LangString{Value: "kitty", Lang: "EN-us"}, Label: nil},
+		},
+		query: &HasLanguage{
+			From:     &Vertex{Values: []quad.Value{}},
+			Language: "en",
+		},
+		results: []interface{}{
+			map[string]string{"@value": "cat", "@language": "en"},
+		},
+	},
+	{
+		name: "HasLanguage Case Insensitive",
+		data: []quad.Quad{
+			{Subject: quad.IRI("cat"), Predicate: quad.IRI("label"), Object: quad.LangString{Value: "cat", Lang: "en-US"}, Label: nil},
+			{Subject: quad.IRI("cat"), Predicate: quad.IRI("label"), Object: quad.LangString{Value: "chat", Lang: "fr"}, Label: nil},
+		},
+		query: &HasLanguage{
+			From:     &Vertex{Values: []quad.Value{}},
+			Language: "en-us",
+		},
+		results: []interface{}{
+			map[string]string{"@value": "cat", "@language": "en-US"},
+		},
+	},
+	{
+		name: "Visit Labels",
+		data: []quad.Quad{
+			quad.MakeIRI("alice", "likes", "bob", "work"),
+			quad.MakeIRI("alice", "likes", "dan", "home"),
+		},
+		query: &Visit{
+			From:       &Vertex{Values: []quad.Value{quad.IRI("alice")}},
+			Properties: PropertyPath{PropertyIRIString("likes")},
+			Labels:     []quad.Value{quad.IRI("work")},
+		},
+		results: []interface{}{
+			map[string]string{"@id": "bob"},
+		},
+	},
+	{
+		name: "Visit Labels Scoped",
+		data: []quad.Quad{
+			quad.MakeIRI("alice", "likes", "bob", "work"),
+			quad.MakeIRI("alice", "likes", "dan", "home"),
+			quad.MakeIRI("bob", "likes", "dan", "home"),
+		},
+		query: &Visit{
+			From: &Visit{
+				From:       &Vertex{Values: []quad.Value{quad.IRI("alice")}},
+				Properties: PropertyPath{PropertyIRIString("likes")},
+				Labels:     []quad.Value{quad.IRI("work")},
+			},
+			Properties: PropertyPath{PropertyIRIString("likes")},
+		},
+		results: []interface{}{
+			map[string]string{"@id": "dan"},
+		},
+	},
+	{
+		name: "Visit duplicates",
+		data: []quad.Quad{
+			quad.MakeIRI("alice", "likes", "bob", ""),
+			quad.MakeIRI("alice", "follows", "bob", ""),
+		},
+		query: &Visit{
+			From:       &Vertex{Values: []quad.Value{quad.IRI("alice")}},
+			Properties: PropertyPath{PropertyIRIStrings{"likes", "follows"}},
+		},
+		results: []interface{}{
+			map[string]string{"@id": "bob"},
+			map[string]string{"@id": "bob"},
+		},
+	},
+	{
+		name: "Visit Alternative Properties",
+		data: []quad.Quad{
+			quad.MakeIRI("alice", "likes", "bob", ""),
+			quad.MakeIRI("alice", "follows", "dan", ""),
+			quad.MakeIRI("alice", "knows", "eve", ""),
+		},
+		query: &Visit{
+			From:       &Vertex{Values: []quad.Value{quad.IRI("alice")}},
+			Properties: PropertyPath{PropertyIRIs{quad.IRI("likes"), quad.IRI("follows")}},
+		},
+		results: []interface{}{
+			map[string]string{"@id": "bob"},
+			map[string]string{"@id": "dan"},
+		},
+	},
+	{
+		name: "Visit Property Sequence",
+		data: []quad.Quad{
+			quad.MakeIRI("alice", "likes", "bob", ""),
+			quad.MakeIRI("alice", "name", "Alice", ""),
+			quad.MakeIRI("bob", "name", "Bob", ""),
+		},
+		query: &Visit{
+			From: &Vertex{Values: []quad.Value{quad.IRI("alice")}},
+			Properties: PropertyPath{PropertySequence{
+				PropertyPath{PropertyIRIString("likes")},
+				PropertyPath{PropertyIRIString("name")},
+			}},
+		},
+		results: []interface{}{
+			map[string]string{"@id": "Bob"},
+		},
+	},
+	{
+		name: "Visit Inverse Property",
+		data: []quad.Quad{
+			quad.MakeIRI("alice", "likes", "bob", ""),
+			quad.MakeIRI("alice", "name", "Alice", ""),
+			quad.MakeIRI("bob", "likes", "dan", ""),
+			quad.MakeIRI("bob", "name", "Bob", ""),
+		},
+		query: &Visit{
+			From: &Vertex{Values: []quad.Value{quad.IRI("bob")}},
+			Properties: PropertyPath{PropertySequence{
+				PropertyPath{PropertyInverse{PropertyPath{PropertyIRIString("likes")}}},
+				PropertyPath{PropertyIRIString("name")},
+			}},
+		},
+		results: []interface{}{
+			map[string]string{"@id": "Alice"},
+		},
+	},
+	{
+		name: "Visit Inverse Property Sequence",
+		data: []quad.Quad{
+			quad.MakeIRI("alice", "likes", "bob", ""),
+			quad.MakeIRI("alice", "name", "Alice", ""),
+			quad.MakeIRI("bob", "name", "Bob", ""),
+		},
+		query: &Visit{
+			From: &Vertex{Values: []quad.Value{quad.IRI("Bob")}},
+			Properties: PropertyPath{PropertyInverse{PropertyPath{PropertySequence{
+				PropertyPath{PropertyIRIString("likes")},
+				PropertyPath{PropertyIRIString("name")},
+			}}}},
+		},
+		results: []interface{}{
+			map[string]string{"@id": "alice"},
+		},
+	},
+	{
+		name: "VisitReverse Property Sequence",
+		data: []quad.Quad{
+			quad.MakeIRI("alice", "likes", "bob", ""),
+			quad.MakeIRI("dan", "likes", "carol", ""),
+			quad.MakeIRI("alice", "name", "Alice", ""),
+			quad.MakeIRI("bob", "name", "Bob", ""),
+		},
+		query: &VisitReverse{
+			From: &Vertex{Values: []quad.Value{quad.IRI("Bob")}},
+			Properties: PropertyPath{PropertySequence{
+				PropertyPath{PropertyIRIString("likes")},
+				PropertyPath{PropertyIRIString("name")},
+			}},
+		},
+		results: []interface{}{
+			map[string]string{"@id": "alice"},
+		},
+	},
+	{
+		name: "Has Property Sequence",
+		data: []quad.Quad{
+			quad.MakeIRI("alice", "likes", "bob", ""),
+			quad.MakeIRI("dan", "likes", "carol", ""),
+			quad.MakeIRI("alice", "name", "Alice", ""),
+			quad.MakeIRI("bob", "name", "Bob", ""),
+		},
+		query: &Has{
+			From: &Vertex{},
+			Property: PropertyPath{PropertySequence{
+				PropertyPath{PropertyIRIString("likes")},
+				PropertyPath{PropertyIRIString("name")},
+			}},
+		},
+		results: []interface{}{
+			map[string]string{"@id": "alice"},
+		},
+	},
+	{
+		name: "Has Property Sequence Values",
+		data: []quad.Quad{
+			quad.MakeIRI("alice", "likes", "bob", ""),
+			quad.MakeIRI("dan", "likes", "carol", ""),
+			quad.MakeIRI("alice", "name", "Alice", ""),
+			quad.MakeIRI("bob", "name", "Bob", ""),
+		},
+		query: &Has{
+			From: &Vertex{Values: []quad.Value{quad.IRI("alice"), quad.IRI("dan")}},
+			Property: PropertyPath{PropertySequence{
+				PropertyPath{PropertyIRIString("likes")},
+				PropertyPath{PropertyIRIString("name")},
+			}},
+			Values: []quad.Value{quad.IRI("Bob")},
+		},
+		results: []interface{}{
+			map[string]string{"@id": "alice"},
+		},
+	},
+	{
+		name: "HasReverse Inverse Property",
+		data: []quad.Quad{
+			quad.MakeIRI("alice", "likes", "bob", ""),
+			quad.MakeIRI("dan", "likes", "carol", ""),
+			quad.MakeIRI("alice", "name", "Alice", ""),
+			quad.MakeIRI("bob", "name", "Bob", ""),
+		},
+		query: &HasReverse{
+			From:     &Vertex{Values: []quad.Value{quad.IRI("alice"), quad.IRI("bob"), quad.IRI("dan")}},
+			Property: PropertyPath{PropertyInverse{PropertyPath{PropertyIRIString("likes")}}},
+			Values:   []quad.Value{quad.IRI("carol")},
+		},
+		results: []interface{}{
+			map[string]string{"@id": "dan"},
+		},
+	},
+	{
+		name: "Visit Zero Or More",
+		data: []quad.Quad{
+			quad.MakeIRI("alice", "knows", "bob", ""),
+			quad.MakeIRI("bob", "knows", "carol", ""),
+			quad.MakeIRI("carol", "knows", "alice", ""),
+			quad.MakeIRI("dan", "knows", "alice", ""),
+		},
+		query: &Visit{
+			From:       &Vertex{Values: []quad.Value{quad.IRI("alice")}},
+			Properties: PropertyPath{PropertyZeroOrMore{PropertyPath{PropertyIRIString("knows")}}},
+		},
+		results: []interface{}{
+			map[string]string{"@id": "alice"},
+			map[string]string{"@id": "bob"},
+			map[string]string{"@id": "carol"},
+		},
+	},
+	{
+		name: "Visit Unique",
+		data: []quad.Quad{
+			quad.MakeIRI("alice", "likes", "bob", ""),
+			quad.MakeIRI("alice", "follows", "bob", ""),
+		},
+		query: &Visit{
+			From:       &Vertex{Values: []quad.Value{quad.IRI("alice")}},
+			Properties: PropertyPath{PropertyIRIStrings{"likes", "follows"}},
+			Unique:     true,
+		},
+		results: []interface{}{
+			map[string]string{"@id": "bob"},
+		},
+	},
+	{
+		name: "Visit LabelTag",
+		data: []quad.Quad{
+			quad.MakeIRI("alice", "likes", "bob", "work"),
+			quad.MakeIRI("alice", "likes", "dan", "home"),
+		},
+		query: &Select{
+			From: &As{
+				From: &Visit{
+					From:       &Vertex{Values: []quad.Value{quad.IRI("alice")}},
+					Properties: PropertyPath{PropertyIRIString("likes")},
+					LabelTag:   "context",
+				},
+				Name: "liked",
+			},
+		},
+		results: []interface{}{
+			map[string]interface{}{
+				"liked":   map[string]string{"@id": "bob"},
+				"context": map[string]string{"@id": "work"},
+			},
+			map[string]interface{}{
+				"liked":   map[string]string{"@id": "dan"},
+				"context": map[string]string{"@id": "home"},
+			},
+		},
+	},
+	{
+		name: "Visit LabelTag with Labels",
+		data: []quad.Quad{
+			quad.MakeIRI("alice", "likes", "bob", "work"),
+			quad.MakeIRI("alice", "likes", "dan", "home"),
+		},
+		query: &Select{
+			From: &Visit{
+				From:       &Vertex{Values: []quad.Value{quad.IRI("alice")}},
+				Properties: PropertyPath{PropertyIRIString("likes")},
+				Labels:     []quad.Value{quad.IRI("home")},
+				LabelTag:   "context",
+			},
+		},
+		results: []interface{}{
+			map[string]interface{}{
+				"context": map[string]string{"@id": "home"},
+			},
+		},
+	},
+	{
+		name: "CountValues",
+		data: []quad.Quad{
+			quad.MakeIRI("bob", "likes", "alice", ""),
+			quad.MakeIRI("dan", "likes", "alice", ""),
+			quad.MakeIRI("alice", "likes", "bob", ""),
+		},
+		query: &CountValues{
+			From: &Visit{
+				From:       &Vertex{},
+				Properties: PropertyPath{PropertyIRIString("likes")},
+			},
+		},
+		results: []interface{}{
+			map[string]interface{}{"@id": "alice", "count": int64(2)},
+			map[string]interface{}{"@id": "bob", "count": int64(1)},
+		},
+	},
+	{
+		name: "HasAny",
+		data: []quad.Quad{
+			quad.MakeIRI("alice", "likes", "bob", ""),
+			quad.MakeIRI("dan", "follows", "bob", ""),
+			quad.MakeIRI("eve", "follows", "alice", ""),
+			quad.MakeIRI("fred", "knows", "bob", ""),
+		},
+		query: &HasAny{
+			From: &Vertex{},
+			Properties: []PropertyPath{
+				{PropertyIRIString("likes")},
+				{PropertyIRIString("follows")},
+			},
+			Values: []quad.Value{quad.IRI("bob")},
+		},
+		results: []interface{}{
+			map[string]string{"@id": "alice"},
+			map[string]string{"@id": "dan"},
+		},
+	},
+	{
+		name: "HasAny Without Values",
+		data: []quad.Quad{
+			quad.MakeIRI("alice", "likes", "bob", ""),
+			quad.MakeIRI("dan", "follows", "bob", ""),
+			quad.MakeIRI("eve", "knows", "alice", ""),
+		},
+		query: &HasAny{
+			From: &Vertex{},
+			Properties: []PropertyPath{
+				{PropertyIRIString("likes")},
+				{PropertyIRIString("follows")},
+			},
+		},
+		results: []interface{}{
+			map[string]string{"@id": "alice"},
+			map[string]string{"@id": "dan"},
+		},
+	},
+	{
+		name: "ShortestPath",
+		data: []quad.Quad{
+			quad.MakeIRI("alice", "knows", "bob", ""),
+			quad.MakeIRI("bob", "knows", "carol", ""),
+			quad.MakeIRI("carol", "knows", "dan", ""),
+			quad.MakeIRI("alice", "likes", "dan", ""),
+		},
+		query: &ShortestPath{
+			From: &Vertex{Values: []quad.Value{quad.IRI("alice")}},
+			To:   []quad.Value{quad.IRI("dan")},
+			Via:  PropertyPath{PropertyIRIString("knows")},
+		},
+		results: []interface{}{
+			map[string]interface{}{
+				"@list": []interface{}{
+					map[string]string{"@id": "alice"},
+					map[string]string{"@id": "bob"},
+					map[string]string{"@id": "carol"},
+					map[string]string{"@id": "dan"},
+				},
+			},
+		},
+	},
+	{
+		name: "ShortestPath no path",
+		data: []quad.Quad{
+			quad.MakeIRI("alice", "knows", "bob", ""),
+			quad.MakeIRI("carol", "knows", "dan", ""),
+		},
+		query: &ShortestPath{
+			From: &Vertex{Values: []quad.Value{quad.IRI("alice")}},
+			To:   []quad.Value{quad.IRI("dan")},
+			Via:  PropertyPath{PropertyIRIString("knows")},
+		},
+		results: nil,
+	},
+	{
+		name: "Neighbors one hop",
+		data: []quad.Quad{
+			quad.MakeIRI("alice", "knows", "bob", ""),
+			quad.MakeIRI("bob", "knows", "carol", ""),
+			quad.MakeIRI("carol", "knows", "alice", ""),
+			quad.MakeIRI("carol", "likes", "dan", ""),
+		},
+		query: &Order{
+			From: &Neighbors{
+				From:    &Vertex{Values: []quad.Value{quad.IRI("alice")}},
+				Via:     PropertyPath{PropertyIRIString("knows")},
+				MaxHops: 1,
+			},
+		},
+		results: []interface{}{
+			map[string]string{"@id": "bob"},
+		},
+	},
+	{
+		name: "Neighbors two hops",
+		data: []quad.Quad{
+			quad.MakeIRI("alice", "knows", "bob", ""),
+			quad.MakeIRI("bob", "knows", "carol", ""),
+			quad.MakeIRI("carol", "knows", "alice", ""),
+			quad.MakeIRI("carol", "likes", "dan", ""),
+		},
+		query: &Order{
+			From: &Neighbors{
+				From:    &Vertex{Values: []quad.Value{quad.IRI("alice")}},
+				Via:     PropertyPath{PropertyIRIString("knows")},
+				MaxHops: 2,
+			},
+		},
+		results: []interface{}{
+			map[string]string{"@id": "bob"},
+			map[string]string{"@id": "carol"},
+		},
+	},
+	{
+		name: "Neighbors ignores loops",
+		data: []quad.Quad{
+			quad.MakeIRI("alice", "knows", "bob", ""),
+			quad.MakeIRI("bob", "knows", "carol", ""),
+			quad.MakeIRI("carol", "knows", "alice", ""),
+			quad.MakeIRI("carol", "likes", "dan", ""),
+		},
+		query: &Order{
+			From: &Neighbors{
+				From:    &Vertex{Values: []quad.Value{quad.IRI("alice")}},
+				Via:     PropertyPath{PropertyIRIString("knows")},
+				MaxHops: 5,
+			},
+		},
+		results: []interface{}{
+			map[string]string{"@id": "bob"},
+			map[string]string{"@id": "carol"},
+		},
+	},
+	{
+		name: "Neighbors include start",
+		data: []quad.Quad{
+			quad.MakeIRI("alice", "knows", "bob", ""),
+			quad.MakeIRI("bob", "knows", "carol", ""),
+			quad.MakeIRI("carol", "knows", "alice", ""),
+			quad.MakeIRI("carol", "likes", "dan", ""),
+		},
+		query: &Order{
+			From: &Neighbors{
+				From:         &Vertex{Values: []quad.Value{quad.IRI("alice")}},
+				Via:          PropertyPath{PropertyIRIString("knows")},
+				MaxHops:      2,
+				IncludeStart: true,
+			},
+		},
+		results: []interface{}{
+			map[string]string{"@id": "alice"},
+			map[string]string{"@id": "bob"},
+			map[string]string{"@id": "carol"},
+		},
+	},
+	{
+		name: "GroupBy",
+		data: []quad.Quad{
+			quad.MakeIRI("alice", "city", "paris", ""),
+			quad.MakeIRI("bob", "city", "london", ""),
+			quad.MakeIRI("carol", "city", "paris", ""),
+			quad.MakeIRI("alice", "likes", "bob", ""),
+		},
+		query: &GroupBy{
+			From: &As{
+				From: &Has{
+					From:     &Vertex{},
+					Property: PropertyPath{PropertyIRIString("city")},
+				},
+				Name: "person",
+			},
+			Key: PropertyPath{PropertyIRIString("city")},
+		},
+		results: []interface{}{
+			map[string]interface{}{
+				"@id": "paris",
+				"members": []interface{}{
+					map[string]interface{}{"@id": "alice", "person": []interface{}{map[string]string{"@id": "alice"}}},
+					map[string]interface{}{"@id": "carol", "person": []interface{}{map[string]string{"@id": "carol"}}},
+				},
+			},
+			map[string]interface{}{
+				"@id": "london",
+				"members": []interface{}{
+					map[string]interface{}{"@id": "bob", "person": []interface{}{map[string]string{"@id": "bob"}}},
+				},
+			},
+		},
 	},
 	{
-		name: "PropertyNamesAs",
+		name: "Tail",
 		data: singleQuadData,
-		query: &Select{
-			From: &PropertyNamesAs{
-				From: &Vertex{Values: []quad.Value{}},
-				Tag:  "predicate",
-			},
+		query: &Tail{
+			From:  &Order{From: &Vertex{}},
+			Count: 2,
+		},
+		results: []interface{}{
+			map[string]string{"@id": "bob"},
+			map[string]string{"@id": "likes"},
 		},
-		results: []interface{}{map[string]interface{}{"predicate": map[string]string{"@id": "likes"}}},
 	},
 	{
-		name: "ReverseProperties",
+		name: "Tail more than results",
 		data: singleQuadData,
-		query: &Select{
-			From: &ReverseProperties{
-				From:  &Vertex{Values: []quad.Value{}},
-				Names: []quad.IRI{quad.IRI("likes")},
-			},
+		query: &Tail{
+			From:  &Order{From: &Vertex{}},
+			Count: 5,
+		},
+		results: []interface{}{
+			map[string]string{"@id": "alice"},
+			map[string]string{"@id": "bob"},
+			map[string]string{"@id": "likes"},
 		},
-		results: []interface{}{map[string]interface{}{"likes": map[string]string{"@id": "alice"}}},
 	},
 	{
-		name: "Skip",
-		data: singleQuadData,
-		query: &Skip{
-			Offset: 2,
-			From: &Vertex{
-				Values: []quad.Value{},
-			},
+		name: "Sample",
+		data: []quad.Quad{
+			quad.MakeIRI("alice", "likes", "bob", ""),
+			quad.MakeIRI("carol", "likes", "dan", ""),
+		},
+		query: &Sample{
+			From:  &Vertex{},
+			Count: 2,
+			Seed:  4,
 		},
 		results: []interface{}{
+			map[string]string{"@id": "carol"},
 			map[string]string{"@id": "bob"},
 		},
 	},
 	{
-		name: "Union",
+		name: "Page first",
 		data: singleQuadData,
-		query: &Union{
-			From: &Vertex{
-				Values: []quad.Value{quad.IRI("alice")},
-			},
-			Steps: []PathStep{
-				&Vertex{
-					Values: []quad.Value{quad.IRI("bob")},
-				},
-			},
+		query: &Page{
+			From:   &Order{From: &Vertex{}},
+			Number: 1,
+			Size:   2,
 		},
 		results: []interface{}{
 			map[string]string{"@id": "alice"},
@@ -434,144 +3136,259 @@ var testCases = []struct {
 		},
 	},
 	{
-		name: "SelectFirst",
+		name: "Page second",
 		data: singleQuadData,
-		query: &SelectFirst{
-			From: &As{
-				From: &Visit{
-					From: &As{
-						Name: "liker",
-						From: &Vertex{},
-					},
-					Properties: PropertyPath{&Vertex{Values: []quad.Value{quad.IRI("likes")}}},
-				},
-				Name: "liked",
-			},
+		query: &Page{
+			From:   &Order{From: &Vertex{}},
+			Number: 2,
+			Size:   2,
+		},
+		results: []interface{}{
+			map[string]string{"@id": "likes"},
 		},
-		results: []interface{}{map[string]interface{}{"liked": map[string]string{"@id": "bob"}, "liker": map[string]string{"@id": "alice"}}},
 	},
 	{
-		name: "Unique",
-		data: singleQuadData,
-		query: &Unique{
-			From: &Vertex{
-				Values: []quad.Value{quad.IRI("alice"), quad.IRI("alice"), quad.IRI("bob")},
-			},
+		name: "HasRegExp",
+		data: []quad.Quad{
+			quad.Make(quad.IRI("alice"), quad.IRI("name"), quad.String("Alice"), nil),
+			quad.Make(quad.IRI("bob"), quad.IRI("name"), quad.String("Bob"), nil),
+			quad.Make(quad.IRI("amy"), quad.IRI("name"), quad.String("Amy"), nil),
+			quad.MakeIRI("bob", "likes", "Anna", ""),
+		},
+		query: &HasRegExp{
+			From:     &Vertex{},
+			Property: PropertyPath{PropertyIRIString("name")},
+			Pattern:  "^A",
 		},
 		results: []interface{}{
 			map[string]string{"@id": "alice"},
-			map[string]string{"@id": "bob"},
+			map[string]string{"@id": "amy"},
 		},
 	},
 	{
-		name: "Order",
-		data: singleQuadData,
-		query: &Order{
-			From: &Vertex{},
+		name: "HasRegExp IRI",
+		data: []quad.Quad{
+			quad.MakeIRI("alice", "likes", "bob", ""),
+			quad.MakeIRI("carol", "likes", "dan", ""),
+		},
+		query: &HasRegExp{
+			From:     &Vertex{},
+			Property: PropertyPath{PropertyIRIString("likes")},
+			Pattern:  "^b",
 		},
 		results: []interface{}{
 			map[string]string{"@id": "alice"},
-			map[string]string{"@id": "bob"},
-			map[string]string{"@id": "likes"},
 		},
 	},
 	{
-		name: "Optional",
+		name: "Filter and",
 		data: []quad.Quad{
-			quad.MakeIRI("alice", "likes", "bob", ""),
-			quad.MakeIRI("alice", "name", "Alice", ""),
-			quad.MakeIRI("bob", "name", "Bob", ""),
+			quad.Make(quad.IRI("a"), quad.IRI("value"), quad.Int(-1), nil),
+			quad.Make(quad.IRI("b"), quad.IRI("value"), quad.Int(5), nil),
+			quad.Make(quad.IRI("c"), quad.IRI("value"), quad.Int(12), nil),
 		},
-		query: &Select{
-			From: &Optional{
-				From: &Properties{
-					From:  &Vertex{Values: []quad.Value{}},
-					Names: []quad.IRI{quad.IRI("name")},
+		query: &Filter{
+			From: &Visit{
+				From:       &Vertex{},
+				Properties: PropertyPath{PropertyIRIString("value")},
+			},
+			Operator: "and",
+			Steps: []PathStep{
+				&GreaterThan{From: &Placeholder{}, Value: quad.Int(0)},
+				&LessThan{From: &Placeholder{}, Value: quad.Int(10)},
+			},
+		},
+		results: []interface{}{
+			map[string]string{"@value": "5", "@type": "xsd:integer"},
+		},
+	},
+	{
+		name: "Filter and int and float",
+		data: []quad.Quad{
+			quad.Make(quad.IRI("a"), quad.IRI("value"), quad.Int(-1), nil),
+			quad.Make(quad.IRI("b"), quad.IRI("value"), quad.Float(5.5), nil),
+			quad.Make(quad.IRI("c"), quad.IRI("value"), quad.Float(12.5), nil),
+		},
+		query: &Filter{
+			From: &Visit{
+				From:       &Vertex{},
+				Properties: PropertyPath{PropertyIRIString("value")},
+			},
+			Operator: "and",
+			Steps: []PathStep{
+				&LessThan{From: &Placeholder{}, Value: quad.Int(10)},
+				&GreaterThan{From: &Placeholder{}, Value: quad.Int(0)},
+			},
+		},
+		results: []interface{}{
+			map[string]string{"@value": "5.5E+00", "@type": "xsd:double"},
+		},
+	},
+	{
+		name: "Filter or",
+		data: []quad.Quad{
+			quad.Make(quad.IRI("a"), quad.IRI("value"), quad.Int(-1), nil),
+			quad.Make(quad.IRI("b"), quad.IRI("value"), quad.Int(5), nil),
+			quad.Make(quad.IRI("c"), quad.IRI("value"), quad.Int(12), nil),
+		},
+		query: &Order{
+			From: &Filter{
+				From: &Visit{
+					From:       &Vertex{},
+					Properties: PropertyPath{PropertyIRIString("value")},
 				},
-				Step: &Properties{
-					From:  &Placeholder{},
-					Names: []quad.IRI{quad.IRI("likes")},
+				Operator: "or",
+				Steps: []PathStep{
+					&LessThan{From: &Placeholder{}, Value: quad.Int(0)},
+					&GreaterThan{From: &Placeholder{}, Value: quad.Int(10)},
 				},
 			},
 		},
 		results: []interface{}{
-			map[string]interface{}{
-				"likes": map[string]string{"@id": "bob"},
-				"name":  map[string]string{"@id": "Alice"},
+			map[string]string{"@value": "-1", "@type": "xsd:integer"},
+			map[string]string{"@value": "12", "@type": "xsd:integer"},
+		},
+	},
+	{
+		name: "Filter or overlapping",
+		data: []quad.Quad{
+			quad.Make(quad.IRI("a"), quad.IRI("value"), quad.Int(-1), nil),
+			quad.Make(quad.IRI("b"), quad.IRI("value"), quad.Int(5), nil),
+			quad.Make(quad.IRI("c"), quad.IRI("value"), quad.Int(12), nil),
+		},
+		query: &Order{
+			From: &Filter{
+				From: &Visit{
+					From:       &Vertex{},
+					Properties: PropertyPath{PropertyIRIString("value")},
+				},
+				Operator: "or",
+				Steps: []PathStep{
+					&GreaterThan{From: &Placeholder{}, Value: quad.Int(0)},
+					&LessThan{From: &Placeholder{}, Value: quad.Int(10)},
+				},
 			},
-			map[string]interface{}{
-				"name": map[string]string{"@id": "Bob"},
+		},
+		results: []interface{}{
+			map[string]string{"@value": "-1", "@type": "xsd:integer"},
+			map[string]string{"@value": "12", "@type": "xsd:integer"},
+			map[string]string{"@value": "5", "@type": "xsd:integer"},
+		},
+	},
+	{
+		name: "Not",
+		data: []quad.Quad{
+			quad.MakeIRI("alice", "likes", "bob", ""),
+			quad.MakeIRI("carol", "likes", "dan", ""),
+		},
+		query: &Not{
+			From: &Vertex{Values: []quad.Value{quad.IRI("alice"), quad.IRI("bob"), quad.IRI("carol")}},
+			Step: &Has{
+				From:     &Placeholder{},
+				Property: PropertyPath{PropertyIRIString("likes")},
+				Values:   []quad.Value{quad.IRI("bob")},
 			},
 		},
+		results: []interface{}{
+			map[string]string{"@id": "bob"},
+			map[string]string{"@id": "carol"},
+		},
 	},
 	{
-		name: "Where",
+		name: "Construct",
 		data: []quad.Quad{
 			quad.MakeIRI("alice", "likes", "bob", ""),
-			quad.MakeIRI("alice", "name", "Alice", ""),
-			quad.MakeIRI("bob", "name", "Bob", ""),
+			quad.MakeIRI("bob", "likes", "carol", ""),
 		},
-		query: &Select{
+		query: &Construct{
 			From: &As{
 				From: &Where{
 					From: &Vertex{},
 					Steps: []PathStep{
-						&As{
-							From: &Visit{
-								From: &Visit{
-									From:       &Placeholder{},
-									Properties: PropertyPath{&Vertex{Values: []quad.Value{quad.IRI("likes")}}},
-								},
-								Properties: PropertyPath{&Vertex{Values: []quad.Value{quad.IRI("name")}}},
-							},
-							Name: "likesName",
-						},
 						&As{
 							From: &Visit{
 								From:       &Placeholder{},
-								Properties: PropertyPath{&Vertex{Values: []quad.Value{quad.IRI("name")}}},
+								Properties: PropertyPath{PropertyIRIString("likes")},
 							},
-							Name: "name",
+							Name: "friend",
 						},
 					},
 				},
 				Name: "person",
 			},
+			Subject:   "person",
+			Predicate: quad.IRI("knows"),
+			Object:    "friend",
 		},
 		results: []interface{}{
-			map[string]interface{}{
-				"person":    map[string]string{"@id": "alice"},
-				"name":      map[string]string{"@id": "Alice"},
-				"likesName": map[string]string{"@id": "Bob"},
-			},
+			quad.MakeIRI("alice", "knows", "bob", ""),
+			quad.MakeIRI("bob", "knows", "carol", ""),
 		},
 	},
 	{
-		name: "Documents",
+		name: "ValueType",
 		data: []quad.Quad{
-			quad.MakeIRI("alice", "likes", "bob", ""),
-			quad.MakeIRI("alice", "name", "Alice", ""),
-			quad.MakeIRI("bob", "name", "Bob", ""),
-			quad.MakeIRI("bob", "likes", "alice", ""),
+			quad.Make(quad.IRI("alice"), quad.IRI("name"), quad.String("Alice"), nil),
+			quad.Make(quad.IRI("alice"), quad.IRI("age"), quad.Int(30), nil),
+			quad.Make(quad.IRI("alice"), quad.IRI("knows"), quad.BNode("b1"), nil),
 		},
-		query: &Documents{
-			From: &Properties{
-				From:  &Vertex{Values: []quad.Value{}},
-				Names: []quad.IRI{quad.IRI("name"), quad.IRI("likes")},
+		query: &ValueType{
+			From: &Visit{
+				From:       &Vertex{Values: []quad.Value{quad.IRI("alice")}},
+				Properties: PropertyPath{&Vertex{Values: []quad.Value{quad.IRI("name"), quad.IRI("age"), quad.IRI("knows")}}},
 			},
 		},
 		results: []interface{}{
-			map[string]interface{}{
-				"@id":   "alice",
-				"name":  []interface{}{map[string]string{"@id": "Alice"}},
-				"likes": []interface{}{map[string]string{"@id": "bob"}},
-			},
-			map[string]interface{}{
-				"@id":   "bob",
-				"name":  []interface{}{map[string]string{"@id": "Bob"}},
-				"likes": []interface{}{map[string]string{"@id": "alice"}},
-			},
+			"string",
+			"int",
+			"bnode",
+		},
+	},
+	{
+		name: "ValueType of IRI",
+		data: singleQuadData,
+		query: &ValueType{
+			From: &Vertex{Values: []quad.Value{quad.IRI("alice")}},
+		},
+		results: []interface{}{
+			"iri",
+		},
+	},
+	{
+		name: "DetectCycles",
+		data: []quad.Quad{
+			quad.MakeIRI("a", "parent", "b", ""),
+			quad.MakeIRI("b", "parent", "a", ""),
+			quad.MakeIRI("a", "parent", "c", ""),
+			quad.MakeIRI("c", "parent", "b", ""),
+			quad.MakeIRI("c", "parent", "d", ""),
+			quad.MakeIRI("d", "parent", "e", ""),
+			quad.MakeIRI("e", "parent", "e", ""),
+			quad.MakeIRI("x", "parent", "y", ""),
+			quad.MakeIRI("y", "parent", "x", ""),
+		},
+		query: &DetectCycles{
+			From: &Vertex{Values: []quad.Value{quad.IRI("a")}},
+			Via:  PropertyPath{PropertyIRIString("parent")},
+		},
+		results: []interface{}{
+			map[string]string{"@id": "a"},
+			map[string]string{"@id": "b"},
+			map[string]string{"@id": "c"},
+			map[string]string{"@id": "e"},
+		},
+	},
+	{
+		name: "DetectCycles without cycles",
+		data: []quad.Quad{
+			quad.MakeIRI("a", "parent", "b", ""),
+			quad.MakeIRI("b", "parent", "c", ""),
+		},
+		query: &DetectCycles{
+			From: &Vertex{Values: []quad.Value{quad.IRI("a")}},
+			Via:  PropertyPath{PropertyIRIString("parent")},
 		},
+		results: nil,
 	},
 }
 
@@ -591,3 +3408,300 @@ func TestLinkedQL(t *testing.T) {
 		})
 	}
 }
+
+func TestPageErrors(t *testing.T) {
+	store := memstore.New(singleQuadData...)
+	_, err := (&Page{From: &Vertex{}, Number: 1, Size: 0}).BuildIterator(store)
+	require.Error(t, err)
+	_, err = (&Page{From: &Vertex{}, Number: 0, Size: 2}).BuildIterator(store)
+	require.Error(t, err)
+}
+
+func TestWithTotalNotPath(t *testing.T) {
+	store := memstore.New(singleQuadData...)
+	_, err := (&WithTotal{From: &Documents{From: &Vertex{}}}).BuildIterator(store)
+	require.Error(t, err)
+}
+
+func TestComparisonIncomparableKinds(t *testing.T) {
+	store := memstore.New(
+		quad.Make(quad.IRI("alice"), quad.IRI("age"), quad.Int(5), nil),
+		quad.Make(quad.IRI("bob"), quad.IRI("age"), quad.String("five"), nil),
+		quad.Make(quad.IRI("carol"), quad.IRI("age"), quad.IRI("dan"), nil),
+	)
+	ages := func(names ...string) PathStep {
+		var values []quad.Value
+		for _, name := range names {
+			values = append(values, quad.IRI(name))
+		}
+		return &Visit{
+			From:       &Vertex{Values: values},
+			Properties: PropertyPath{PropertyIRIString("age")},
+		}
+	}
+	ctx := context.TODO()
+	for _, c := range []struct {
+		name  string
+		step  IteratorStep
+		fails bool
+	}{
+		{"IRI with number", &LessThan{From: ages("alice"), Value: quad.IRI("bob")}, true},
+		{"number with string", &GreaterThan{From: ages("bob"), Value: quad.Int(10)}, true},
+		{"string with number", &LessThanEquals{From: ages("alice"), Value: quad.String("zzz")}, true},
+		{"number with IRI", &GreaterThanEquals{From: ages("carol"), Value: quad.Int(1)}, false},
+		{"Filter", &Filter{
+			From:     ages("alice", "bob"),
+			Operator: "and",
+			Steps:    []PathStep{&LessThan{From: &Placeholder{}, Value: quad.Int(10)}},
+		}, true},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			it, err := c.step.BuildIterator(store)
+			require.NoError(t, err)
+			for it.Next(ctx) {
+			}
+			if !c.fails {
+				require.NoError(t, it.Err())
+				return
+			}
+			_, ok := it.Err().(*IncomparableError)
+			require.True(t, ok, "unexpected error: %v", it.Err())
+		})
+	}
+	_, err := (&Between{From: ages("alice"), Min: quad.Int(1), Max: quad.String("z")}).BuildIterator(store)
+	_, ok := err.(*IncomparableError)
+	require.True(t, ok, "unexpected error: %v", err)
+}
+
+func TestComparisonPushdown(t *testing.T) {
+	store := memstore.New()
+	p, err := (&LessThan{From: &Vertex{}, Value: quad.Int(5)}).BuildPath(store)
+	require.NoError(t, err)
+	var comparisons []quad.Value
+	var walk func(s shape.Shape)
+	walk = func(s shape.Shape) {
+		switch s := s.(type) {
+		case shape.Filter:
+			for _, f := range s.Filters {
+				if _, ok := f.(incomparableFilter); ok {
+					continue
+				}
+				c, ok := f.(shape.Comparison)
+				require.True(t, ok, "unexpected filter: %#v", f)
+				comparisons = append(comparisons, c.Val)
+			}
+			walk(s.From)
+		case shape.Union:
+			for _, sub := range s {
+				walk(sub)
+			}
+		}
+	}
+	walk(p.Shape())
+	require.Equal(t, []quad.Value{quad.Int(5), quad.Float(5)}, comparisons)
+}
+
+func TestIntersectResults(t *testing.T) {
+	store := memstore.New(
+		quad.MakeIRI("alice", "likes", "bob", ""),
+		quad.MakeIRI("alice", "likes", "carol", ""),
+		quad.MakeIRI("bob", "likes", "carol", ""),
+		quad.MakeIRI("bob", "likes", "dan", ""),
+		quad.MakeIRI("carol", "likes", "dan", ""),
+		quad.MakeIRI("dan", "likes", "bob", ""),
+		quad.MakeIRI("dan", "likes", "carol", ""),
+		quad.MakeIRI("erin", "likes", "carol", ""),
+		quad.MakeIRI("erin", "likes", "dan", ""),
+	)
+	likes := func(names ...string) PathStep {
+		var values []quad.Value
+		for _, name := range names {
+			values = append(values, quad.IRI(name))
+		}
+		return &Visit{
+			From:       &Vertex{Values: values},
+			Properties: PropertyPath{PropertyIRIString("likes")},
+		}
+	}
+	count := func(p *path.Path) map[string]int {
+		ctx := context.TODO()
+		it := NewValueIterator(p, store)
+		counts := make(map[string]int)
+		for it.Next(ctx) {
+			counts[it.Value().String()]++
+		}
+		require.NoError(t, it.Err())
+		return counts
+	}
+	// an intersection of the paths resolves to the same values, the same number of times
+	for _, c := range []struct {
+		name  string
+		from  PathStep
+		steps []PathStep
+	}{
+		{"all", &Vertex{}, []PathStep{likes("alice", "bob", "dan"), likes("erin")}},
+		{"duplicates", likes("alice", "bob", "dan"), []PathStep{likes("bob", "dan", "erin"), likes("erin")}},
+		{"fixed", likes("alice", "bob", "dan"), []PathStep{likes("bob"), &Vertex{Values: []quad.Value{quad.IRI("carol")}}}},
+		{"empty", likes("alice", "bob"), []PathStep{likes("alice"), likes("frank")}},
+	} {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			fromPath, err := c.from.BuildPath(store)
+			require.NoError(t, err)
+			exp := fromPath
+			for _, step := range c.steps {
+				stepPath, err := step.BuildPath(store)
+				require.NoError(t, err)
+				exp = exp.And(stepPath)
+			}
+			p, err := (&Intersect{From: c.from, Steps: c.steps}).BuildPath(store)
+			require.NoError(t, err)
+			require.Equal(t, count(exp), count(p))
+		})
+	}
+}
+
+func BenchmarkIntersect(b *testing.B) {
+	var data []quad.Quad
+	for i := 0; i < 1000; i++ {
+		data = append(data, quad.Make(quad.IRI("alice"), quad.IRI("likes"), quad.Int(i), nil))
+		data = append(data, quad.Make(quad.IRI("bob"), quad.IRI("likes"), quad.Int(i), nil))
+	}
+	data = append(data, quad.Make(quad.IRI("carol"), quad.IRI("likes"), quad.Int(1), nil))
+	data = append(data, quad.Make(quad.IRI("carol"), quad.IRI("knows"), quad.IRI("dan"), nil))
+	store := memstore.New(data...)
+	likes := func(name string) PathStep {
+		return &Visit{
+			From:       &Vertex{Values: []quad.Value{quad.IRI(name)}},
+			Properties: PropertyPath{PropertyIRIString("likes")},
+		}
+	}
+	run := func(b *testing.B, build func() (*path.Path, error)) {
+		ctx := context.TODO()
+		for i := 0; i < b.N; i++ {
+			p, err := build()
+			require.NoError(b, err)
+			it := NewValueIterator(p, store)
+			for it.Next(ctx) {
+			}
+			require.NoError(b, it.Err())
+		}
+	}
+	// a plain intersection checks the values of bob against alice first, and checks all of them if dan is empty,
+	// Intersect checks them against the smallest step first and iterates nothing if it is empty
+	for _, c := range []struct {
+		name  string
+		steps []PathStep
+	}{
+		{"largest first", []PathStep{likes("carol"), likes("bob")}},
+		{"empty", []PathStep{likes("dan"), likes("bob")}},
+	} {
+		steps := c.steps
+		b.Run(c.name+"/And", func(b *testing.B) {
+			run(b, func() (*path.Path, error) {
+				p, err := likes("alice").BuildPath(store)
+				if err != nil {
+					return nil, err
+				}
+				for _, step := range steps {
+					stepPath, err := step.BuildPath(store)
+					if err != nil {
+						return nil, err
+					}
+					p = p.And(stepPath)
+				}
+				return p, nil
+			})
+		})
+		b.Run(c.name+"/Intersect", func(b *testing.B) {
+			run(b, func() (*path.Path, error) {
+				return (&Intersect{From: likes("alice"), Steps: steps}).BuildPath(store)
+			})
+		})
+	}
+}
+
+func TestFilterErrors(t *testing.T) {
+	store := memstore.New(singleQuadData...)
+	_, err := (&Filter{From: &Vertex{}}).BuildIterator(store)
+	require.Error(t, err)
+	_, err = (&Filter{
+		From:     &Vertex{},
+		Operator: "xor",
+		Steps:    []PathStep{&LessThan{From: &Placeholder{}, Value: quad.Int(0)}},
+	}).BuildIterator(store)
+	require.Error(t, err)
+}
+
+func TestExecuteBlankNodeVertex(t *testing.T) {
+	store := memstore.New(
+		quad.Make(quad.BNode("b1"), quad.IRI("knows"), quad.BNode("b2"), nil),
+		quad.Make(quad.BNode("b2"), quad.IRI("knows"), quad.BNode("b1"), nil),
+	)
+	s := NewSession(store)
+	it, err := s.Execute(context.TODO(), `{
+		"@type": "linkedql:Visit",
+		"linkedql:properties": "knows",
+		"linkedql:from": {
+			"@type": "linkedql:Vertex",
+			"linkedql:values": [{"@id": "_:b1"}]
+		}
+	}`, query.Options{})
+	require.NoError(t, err)
+	var ids []string
+	for it.Next(context.TODO()) {
+		ids = append(ids, it.Result().(map[string]string)["@id"])
+	}
+	require.NoError(t, it.Err())
+	require.Equal(t, []string{"_:b2"}, ids)
+
+	// The blank node reached must be usable as a start point of another query.
+	it, err = s.Execute(context.TODO(), `{
+		"@type": "linkedql:Visit",
+		"linkedql:properties": "knows",
+		"linkedql:from": {
+			"@type": "linkedql:Vertex",
+			"linkedql:values": [{"@id": "`+ids[0]+`"}]
+		}
+	}`, query.Options{})
+	require.NoError(t, err)
+	ids = nil
+	for it.Next(context.TODO()) {
+		ids = append(ids, it.Result().(map[string]string)["@id"])
+	}
+	require.NoError(t, it.Err())
+	require.Equal(t, []string{"_:b1"}, ids)
+}
+
+func TestZeroOrMoreLongChain(t *testing.T) {
+	// longer than the default limit of recursive iterators
+	const hops = 80
+	var quads []quad.Quad
+	for i := 0; i < hops; i++ {
+		quads = append(quads, quad.MakeIRI("n"+strconv.Itoa(i), "next", "n"+strconv.Itoa(i+1), ""))
+	}
+	store := memstore.New(quads...)
+	step := &Visit{
+		From:       &Vertex{Values: []quad.Value{quad.IRI("n0")}},
+		Properties: PropertyPath{PropertyZeroOrMore{PropertyPath{PropertyIRIString("next")}}},
+	}
+	it, err := step.BuildIterator(store)
+	require.NoError(t, err)
+	ctx := context.TODO()
+	n := 0
+	for it.Next(ctx) {
+		n++
+	}
+	require.NoError(t, it.Err())
+	require.Equal(t, hops+1, n)
+}
+
+func TestReverseNotReversible(t *testing.T) {
+	store := memstore.New(singleQuadData...)
+	step := &Reverse{
+		From: &Vertex{},
+		Step: &PropertyNames{From: &Placeholder{}},
+	}
+	_, err := step.BuildIterator(store)
+	require.IsType(t, &path.NotReversibleError{}, err)
+}
@@ -0,0 +1,98 @@
+package linkedql
+
+import (
+	"context"
+	"time"
+
+	"github.com/cayleygraph/cayley/graph"
+	"github.com/cayleygraph/cayley/query"
+)
+
+var _ query.Iterator = (*TimeoutIterator)(nil)
+
+// TimeoutIterator is an iterator stopping once a duration passed since it was first advanced.
+// When it stops because of the timeout, Err returns context.DeadlineExceeded unless Partial is set.
+type TimeoutIterator struct {
+	// Partial makes the iterator stop without an error when a deadline is exceeded,
+	// keeping the results emitted so far. Truncated reports whether it happened.
+	Partial   bool
+	it        query.Iterator
+	timeout   time.Duration
+	deadline  time.Time
+	err       error
+	truncated bool
+}
+
+// NewTimeoutIterator returns a new TimeoutIterator of it.
+func NewTimeoutIterator(it query.Iterator, timeout time.Duration) *TimeoutIterator {
+	return &TimeoutIterator{it: it, timeout: timeout}
+}
+
+// BuildIteratorWithTimeout builds the iterator of step, limiting its execution to timeout.
+func BuildIteratorWithTimeout(step IteratorStep, qs graph.QuadStore, timeout time.Duration) (query.Iterator, error) {
+	it, err := step.BuildIterator(qs)
+	if err != nil {
+		return nil, err
+	}
+	return NewTimeoutIterator(it, timeout), nil
+}
+
+// Next implements query.Iterator.
+// The deadline is applied to ctx on each call, so ctx can still stop the iteration on its own.
+func (it *TimeoutIterator) Next(ctx context.Context) bool {
+	if it.err != nil || it.truncated {
+		return false
+	}
+	if it.deadline.IsZero() {
+		it.deadline = time.Now().Add(it.timeout)
+	}
+	ctx, cancel := context.WithDeadline(ctx, it.deadline)
+	defer cancel()
+	if err := ctx.Err(); err != nil {
+		it.stop(err)
+		return false
+	}
+	if it.it.Next(ctx) {
+		return true
+	}
+	// the wrapped iterator may have completed after the deadline passed
+	if err := it.it.Err(); err != nil && err == ctx.Err() {
+		it.stop(err)
+	}
+	return false
+}
+
+// stop records the error the iteration stopped with.
+func (it *TimeoutIterator) stop(err error) {
+	if it.Partial && err == context.DeadlineExceeded {
+		it.truncated = true
+		return
+	}
+	it.err = err
+}
+
+// Truncated reports whether the iteration stopped early because a deadline was exceeded in Partial mode.
+func (it *TimeoutIterator) Truncated() bool {
+	return it.truncated
+}
+
+// Result implements query.Iterator.
+func (it *TimeoutIterator) Result() interface{} {
+	return it.it.Result()
+}
+
+// Err implements query.Iterator.
+func (it *TimeoutIterator) Err() error {
+	if it.err != nil {
+		return it.err
+	}
+	if it.truncated {
+		return nil
+	}
+	return it.it.Err()
+}
+
+// Close implements query.Iterator.
+func (it *TimeoutIterator) Close() error {
+	return it.it.Close()
+}
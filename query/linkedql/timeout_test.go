@@ -0,0 +1,107 @@
+package linkedql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cayleygraph/cayley/graph/memstore"
+	"github.com/cayleygraph/cayley/query"
+	"github.com/stretchr/testify/require"
+)
+
+var _ query.Iterator = (*slowIterator)(nil)
+
+// slowIterator is an iterator of max results, or an endless one if max is not set, taking delay to advance.
+type slowIterator struct {
+	delay time.Duration
+	max   int
+	n     int
+}
+
+func (it *slowIterator) Next(ctx context.Context) bool {
+	time.Sleep(it.delay)
+	if it.max > 0 && it.n >= it.max {
+		return false
+	}
+	it.n++
+	return true
+}
+
+func (it *slowIterator) Result() interface{} { return it.n }
+func (it *slowIterator) Err() error          { return nil }
+func (it *slowIterator) Close() error        { return nil }
+
+func TestTimeoutIterator(t *testing.T) {
+	it := NewTimeoutIterator(&slowIterator{delay: time.Millisecond}, 20*time.Millisecond)
+	n := 0
+	for it.Next(context.TODO()) {
+		n++
+	}
+	require.Equal(t, context.DeadlineExceeded, it.Err())
+	require.True(t, n > 0)
+	require.NoError(t, it.Close())
+}
+
+func TestTimeoutIteratorPartial(t *testing.T) {
+	it := NewTimeoutIterator(&slowIterator{delay: time.Millisecond}, 20*time.Millisecond)
+	it.Partial = true
+	var results []interface{}
+	for it.Next(context.TODO()) {
+		results = append(results, it.Result())
+	}
+	require.NoError(t, it.Err())
+	require.True(t, it.Truncated())
+	require.NotEmpty(t, results)
+	require.Equal(t, 1, results[0])
+	require.False(t, it.Next(context.TODO()))
+	require.NoError(t, it.Close())
+}
+
+func TestTimeoutIteratorPartialComplete(t *testing.T) {
+	store := memstore.New(singleQuadData...)
+	valueIt, err := NewValueIteratorFromPathStep(&Vertex{}, store)
+	require.NoError(t, err)
+	it := NewTimeoutIterator(valueIt, time.Minute)
+	it.Partial = true
+	n := 0
+	for it.Next(context.TODO()) {
+		n++
+	}
+	require.NoError(t, it.Err())
+	require.False(t, it.Truncated())
+	require.Equal(t, 3, n)
+	require.NoError(t, it.Close())
+}
+
+func TestTimeoutIteratorCompleteAfterDeadline(t *testing.T) {
+	it := NewTimeoutIterator(&slowIterator{delay: 15 * time.Millisecond, max: 1}, 20*time.Millisecond)
+	require.True(t, it.Next(context.TODO()))
+	require.False(t, it.Next(context.TODO()))
+	// the wrapped iterator completed after the deadline, it was not stopped by the timeout
+	require.NoError(t, it.Err())
+	require.NoError(t, it.Close())
+}
+
+func TestTimeoutIteratorCallerContext(t *testing.T) {
+	it := NewTimeoutIterator(&slowIterator{delay: time.Millisecond}, time.Minute)
+	ctx, cancel := context.WithCancel(context.Background())
+	require.True(t, it.Next(ctx))
+	cancel()
+	require.False(t, it.Next(ctx))
+	require.Equal(t, context.Canceled, it.Err())
+	require.NoError(t, it.Close())
+}
+
+func TestBuildIteratorWithTimeout(t *testing.T) {
+	store := memstore.New(singleQuadData...)
+	it, err := BuildIteratorWithTimeout(&Vertex{}, store, time.Minute)
+	require.NoError(t, err)
+	n := 0
+	for it.Next(context.TODO()) {
+		n++
+	}
+	require.NoError(t, it.Err())
+	require.Equal(t, 3, n)
+	require.NoError(t, it.Close())
+}
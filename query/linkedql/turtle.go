@@ -0,0 +1,61 @@
+package linkedql
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/cayleygraph/cayley/query"
+	"github.com/cayleygraph/quad"
+	"github.com/cayleygraph/quad/voc"
+)
+
+// turtleLocalName matches the local names written as prefixed names.
+// Other IRIs are written in full.
+var turtleLocalName = regexp.MustCompile(`^[A-Za-z0-9_]([A-Za-z0-9_.-]*[A-Za-z0-9_-])?$`)
+
+// WriteTurtle writes the quads resulting of it, as returned by Construct, to w as Turtle.
+// The namespaces of ns, which may be nil, are written as @prefix declarations and used to shorten IRIs.
+// Labels are not written as Turtle has no named graphs.
+func WriteTurtle(ctx context.Context, w io.Writer, it query.Iterator, ns *voc.Namespaces) error {
+	var list []voc.Namespace
+	if ns != nil {
+		list = ns.List()
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Prefix < list[j].Prefix })
+	for _, n := range list {
+		if _, err := fmt.Fprintf(w, "@prefix %s <%s> .\n", n.Prefix, n.Full); err != nil {
+			return err
+		}
+	}
+	if len(list) != 0 {
+		if _, err := io.WriteString(w, "\n"); err != nil {
+			return err
+		}
+	}
+	// shorten with the longest matching namespace
+	sort.Slice(list, func(i, j int) bool { return len(list[i].Full) > len(list[j].Full) })
+	term := func(v quad.Value) string {
+		if iri, ok := v.(quad.IRI); ok {
+			for _, n := range list {
+				if local := strings.TrimPrefix(string(iri), n.Full); local != string(iri) && (local == "" || turtleLocalName.MatchString(local)) {
+					return n.Prefix + local
+				}
+			}
+		}
+		return v.String()
+	}
+	for it.Next(ctx) {
+		q, ok := it.Result().(quad.Quad)
+		if !ok {
+			return fmt.Errorf("expected a quad but received %v instead", it.Result())
+		}
+		if _, err := fmt.Fprintf(w, "%s %s %s .\n", term(q.Subject), term(q.Predicate), term(q.Object)); err != nil {
+			return err
+		}
+	}
+	return it.Err()
+}
@@ -0,0 +1,49 @@
+package linkedql
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/cayleygraph/cayley/graph/memstore"
+	"github.com/cayleygraph/quad"
+	"github.com/cayleygraph/quad/voc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteTurtle(t *testing.T) {
+	store := memstore.New(
+		quad.MakeIRI("http://example.com/alice", "http://example.com/likes", "http://example.com/bob", ""),
+		quad.MakeIRI("http://example.com/bob", "http://example.com/likes", "http://other.org/carol", ""),
+	)
+	step := &Construct{
+		From: &As{
+			From: &Where{
+				From: &Vertex{},
+				Steps: []PathStep{
+					&As{
+						From: &Visit{
+							From:       &Placeholder{},
+							Properties: PropertyPath{PropertyIRIString("http://example.com/likes")},
+						},
+						Name: "friend",
+					},
+				},
+			},
+			Name: "person",
+		},
+		Subject:   "person",
+		Predicate: quad.IRI("http://example.com/knows"),
+		Object:    "friend",
+	}
+	it, err := step.BuildIterator(store)
+	require.NoError(t, err)
+	ns := &voc.Namespaces{}
+	ns.Register(voc.Namespace{Prefix: "ex:", Full: "http://example.com/"})
+	var buf bytes.Buffer
+	require.NoError(t, WriteTurtle(context.TODO(), &buf, it, ns))
+	require.Equal(t, "@prefix ex: <http://example.com/> .\n"+
+		"\n"+
+		"ex:alice ex:knows ex:bob .\n"+
+		"ex:bob ex:knows <http://other.org/carol> .\n", buf.String())
+}
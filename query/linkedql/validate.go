@@ -0,0 +1,160 @@
+package linkedql
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var (
+	pathStepType         = reflect.TypeOf((*PathStep)(nil)).Elem()
+	operatorType         = reflect.TypeOf((*Operator)(nil)).Elem()
+	entityIdentifierType = reflect.TypeOf((*EntityIdentifier)(nil)).Elem()
+	registryItemType     = reflect.TypeOf((*RegistryItem)(nil)).Elem()
+	propertyPathType     = reflect.TypeOf(PropertyPath{})
+)
+
+// Validate checks the cardinality of the fields of item and of all the items nested in it.
+// Step, operator, identifier, value and property path fields are required unless tagged with minCardinality:"0".
+// Slice fields may be restricted with the minCardinality and maxCardinality tags.
+// Property path fields must be sets of properties, unless tagged with propertyPath:"any" as
+// sequences, inverse and repeated property paths are only followed by the steps tagging them so.
+func Validate(item RegistryItem) error {
+	v := reflect.ValueOf(item)
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+	return validateFields(item, v)
+}
+
+func validateFields(item RegistryItem, v reflect.Value) error {
+	tp := v.Type()
+	for i := 0; i < tp.NumField(); i++ {
+		f := tp.Field(i)
+		fv := v.Field(i)
+		if f.Anonymous && f.Type.Kind() == reflect.Struct {
+			if err := validateFields(item, fv); err != nil {
+				return err
+			}
+			continue
+		}
+		name, ok := fieldName(f)
+		if !ok {
+			continue
+		}
+		min, max, err := fieldCardinality(f)
+		if err != nil {
+			return err
+		}
+		switch f.Type.Kind() {
+		case reflect.Interface:
+			if fv.IsNil() {
+				if min > 0 {
+					return &ValidationError{Step: item.Type(), Field: name, Reason: "is required"}
+				}
+				continue
+			}
+			if nested, ok := fv.Interface().(RegistryItem); ok {
+				if err := Validate(nested); err != nil {
+					return err
+				}
+			}
+		case reflect.Struct:
+			if f.Type != propertyPathType {
+				continue
+			}
+			pp := fv.Interface().(PropertyPath)
+			if pp.p == nil {
+				if min > 0 {
+					return &ValidationError{Step: item.Type(), Field: name, Reason: "is required"}
+				}
+				continue
+			}
+			if err := validatePropertyPath(item, f, name, pp); err != nil {
+				return err
+			}
+		case reflect.Slice:
+			if min >= 0 && fv.Len() < min {
+				return &ValidationError{Step: item.Type(), Field: name, Reason: "expects at least " + strconv.Itoa(min) + " values"}
+			}
+			if max >= 0 && fv.Len() > max {
+				return &ValidationError{Step: item.Type(), Field: name, Reason: "expects at most " + strconv.Itoa(max) + " values"}
+			}
+			if f.Type.Elem() == propertyPathType {
+				for j := 0; j < fv.Len(); j++ {
+					if err := validatePropertyPath(item, f, name, fv.Index(j).Interface().(PropertyPath)); err != nil {
+						return err
+					}
+				}
+			}
+			if f.Type.Elem().Implements(registryItemType) {
+				for j := 0; j < fv.Len(); j++ {
+					nested, ok := fv.Index(j).Interface().(RegistryItem)
+					if !ok {
+						continue
+					}
+					if err := Validate(nested); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// validatePropertyPath checks that the property path of a field is a set of properties unless the field accepts any property path.
+func validatePropertyPath(item RegistryItem, f reflect.StructField, name string, pp PropertyPath) error {
+	if pp.p == nil || pp.isSet() || f.Tag.Get("propertyPath") == "any" {
+		return nil
+	}
+	return &ValidationError{Step: item.Type(), Field: name, Reason: "must be a set of properties, sequence, inverse and repeated property paths are only supported by Visit, VisitReverse, Has and HasReverse"}
+}
+
+// fieldName returns the JSON name of an exported field or false if the field is not serialized.
+func fieldName(f reflect.StructField) (string, bool) {
+	if f.PkgPath != "" {
+		// unexported
+		return "", false
+	}
+	name := strings.SplitN(f.Tag.Get("json"), ",", 2)[0]
+	if name == "-" {
+		return "", false
+	} else if name == "" {
+		name = f.Name
+	}
+	return name, true
+}
+
+// fieldCardinality returns the minimal and maximal number of values of a field. -1 means not restricted.
+func fieldCardinality(f reflect.StructField) (min, max int, err error) {
+	min, max = -1, -1
+	if s, ok := f.Tag.Lookup("minCardinality"); ok {
+		if min, err = strconv.Atoi(s); err != nil {
+			return 0, 0, err
+		}
+	}
+	if s, ok := f.Tag.Lookup("maxCardinality"); ok {
+		if max, err = strconv.Atoi(s); err != nil {
+			return 0, 0, err
+		}
+	}
+	if min < 0 && isRequiredType(f.Type) {
+		min = 1
+	}
+	return min, max, nil
+}
+
+func isRequiredType(t reflect.Type) bool {
+	switch t {
+	case pathStepType, iteratorStepType, operatorType, entityIdentifierType, quadValue, propertyPathType:
+		return true
+	}
+	return false
+}
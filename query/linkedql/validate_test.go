@@ -0,0 +1,101 @@
+package linkedql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cayleygraph/cayley/query"
+	"github.com/cayleygraph/quad"
+	"github.com/stretchr/testify/require"
+)
+
+var validateCases = []struct {
+	name  string
+	step  Step
+	field string
+}{
+	{
+		name:  "missing from",
+		step:  &Limit{Limit: 10},
+		field: "from",
+	},
+	{
+		name: "missing nested from",
+		step: &Select{
+			From: &Visit{
+				Properties: PropertyPath{PropertyIRIString("likes")},
+			},
+		},
+		field: "from",
+	},
+	{
+		name:  "missing property",
+		step:  &Visit{From: &Vertex{}},
+		field: "properties",
+	},
+	{
+		name:  "missing value in filter steps",
+		step:  &Filter{From: &Vertex{}, Steps: []PathStep{&LessThan{From: &Placeholder{}}}},
+		field: "value",
+	},
+	{
+		name:  "missing value",
+		step:  &LessThan{From: &Vertex{}},
+		field: "value",
+	},
+	{
+		name:  "too few properties",
+		step:  &HasAny{From: &Vertex{}, Values: []quad.Value{quad.IRI("bob")}},
+		field: "properties",
+	},
+	{
+		name: "property sequence",
+		step: &Both{
+			From: &Vertex{},
+			Properties: PropertyPath{PropertySequence{
+				PropertyPath{PropertyIRIString("likes")},
+				PropertyPath{PropertyIRIString("name")},
+			}},
+		},
+		field: "properties",
+	},
+	{
+		name: "inverse property in properties",
+		step: &HasAny{
+			From: &Vertex{},
+			Properties: []PropertyPath{
+				{PropertyIRIString("likes")},
+				{PropertyInverse{PropertyPath{PropertyIRIString("likes")}}},
+			},
+		},
+		field: "properties",
+	},
+}
+
+func TestValidate(t *testing.T) {
+	for _, c := range validateCases {
+		t.Run(c.name, func(t *testing.T) {
+			err := Validate(c.step)
+			require.Error(t, err)
+			verr, ok := err.(*ValidationError)
+			require.True(t, ok, "unexpected error type: %T", err)
+			require.Equal(t, c.field, verr.Field)
+		})
+	}
+}
+
+func TestValidateTestCases(t *testing.T) {
+	for _, c := range testCases {
+		t.Run(c.name, func(t *testing.T) {
+			require.NoError(t, Validate(c.query))
+		})
+	}
+}
+
+func TestExecuteValidates(t *testing.T) {
+	s := NewSession(nil)
+	_, err := s.Execute(context.TODO(), `{"@type": "linkedql:Limit", "linkedql:limit": 10}`, query.Options{})
+	require.Error(t, err)
+	_, ok := err.(*ValidationError)
+	require.True(t, ok, "unexpected error type: %T", err)
+}
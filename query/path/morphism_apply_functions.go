@@ -208,7 +208,7 @@ func labelContextMorphism(tags []string, via ...interface{}) morphism {
 func labelsMorphism() morphism {
 	return morphism{
 		Reversal: func(ctx *pathContext) (morphism, *pathContext) {
-			panic("not implemented")
+			panic(&NotReversibleError{Reason: "not implemented: need a function from labels to their associated edges"})
 		},
 		Apply: func(in shape.Shape, ctx *pathContext) (shape.Shape, *pathContext) {
 			return shape.Labels(in), ctx
@@ -221,7 +221,7 @@ func labelsMorphism() morphism {
 func predicatesMorphism(isIn bool) morphism {
 	return morphism{
 		Reversal: func(ctx *pathContext) (morphism, *pathContext) {
-			panic("not implemented: need a function from predicates to their associated edges")
+			panic(&NotReversibleError{Reason: "not implemented: need a function from predicates to their associated edges"})
 		},
 		Apply: func(in shape.Shape, ctx *pathContext) (shape.Shape, *pathContext) {
 			return shape.Predicates(in, isIn), ctx
@@ -229,6 +229,19 @@ func predicatesMorphism(isIn bool) morphism {
 	}
 }
 
+// predicatesToMorphism iterates to the uniqified set of predicates linking
+// the given set of nodes in the path to the nodes of another path.
+func predicatesToMorphism(to *Path) morphism {
+	return morphism{
+		Reversal: func(ctx *pathContext) (morphism, *pathContext) {
+			panic(&NotReversibleError{Reason: "not implemented: need a function from predicates to their associated edges"})
+		},
+		Apply: func(in shape.Shape, ctx *pathContext) (shape.Shape, *pathContext) {
+			return shape.PredicatesTo(in, to.Shape()), ctx
+		},
+	}
+}
+
 // savePredicatesMorphism tags either forward or reverse predicates from current node
 // without affecting path.
 func savePredicatesMorphism(isIn bool, tag string) morphism {
@@ -437,11 +450,11 @@ func skipMorphism(v int64) morphism {
 	}
 }
 
-func orderMorphism() morphism {
+func orderMorphism(less iterator.Less, desc bool) morphism {
 	return morphism{
-		Reversal: func(ctx *pathContext) (morphism, *pathContext) { return orderMorphism(), ctx },
+		Reversal: func(ctx *pathContext) (morphism, *pathContext) { return orderMorphism(less, desc), ctx },
 		Apply: func(in shape.Shape, ctx *pathContext) (shape.Shape, *pathContext) {
-			return shape.Sort{From: in}, ctx
+			return shape.Sort{From: in, Descending: desc, Less: less}, ctx
 		},
 	}
 }
@@ -144,6 +144,30 @@ func (p *Path) Reverse() *Path {
 	return newPath
 }
 
+// NotReversibleError is the error of reversing a path holding a morphism which can not be reversed.
+type NotReversibleError struct {
+	Reason string
+}
+
+func (e *NotReversibleError) Error() string {
+	return "can not reverse path: " + e.Reason
+}
+
+// ReverseChecked is like Reverse but returns a *NotReversibleError instead of panicking
+// if the path can not be reversed.
+func (p *Path) ReverseChecked() (rp *Path, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			e, ok := r.(*NotReversibleError)
+			if !ok {
+				panic(r)
+			}
+			rp, err = nil, e
+		}
+	}()
+	return p.Reverse(), nil
+}
+
 // Is declares that the current nodes in this path are only the nodes
 // passed as arguments.
 func (p *Path) Is(nodes ...quad.Value) *Path {
@@ -300,6 +324,18 @@ func (p *Path) OutPredicates() *Path {
 	return np
 }
 
+// OutPredicatesTo updates this path to represent the nodes of the predicates
+// linking the current nodes to the nodes of the given path.
+//
+// For example:
+//  // Will return []string{"follows"} if "bob" follows "alice"
+//  StartPath(qs, "bob").OutPredicatesTo(StartPath(qs, "alice"))
+func (p *Path) OutPredicatesTo(to *Path) *Path {
+	np := p.clone()
+	np.stack = append(np.stack, predicatesToMorphism(to))
+	return np
+}
+
 // SavePredicates saves either forward or reverse predicates of current node
 // without changing path location.
 func (p *Path) SavePredicates(rev bool, tag string) *Path {
@@ -544,7 +580,20 @@ func (p *Path) Skip(v int64) *Path {
 }
 
 func (p *Path) Order() *Path {
-	p.stack = append(p.stack, orderMorphism())
+	p.stack = append(p.stack, orderMorphism(nil, false))
+	return p
+}
+
+// OrderDescending is the same as Order, but sorts values in descending order.
+func (p *Path) OrderDescending() *Path {
+	p.stack = append(p.stack, orderMorphism(nil, true))
+	return p
+}
+
+// OrderWith sorts the results using the provided comparator.
+// If desc is set, the order is reversed.
+func (p *Path) OrderWith(less iterator.Less, desc bool) *Path {
+	p.stack = append(p.stack, orderMorphism(less, desc))
 	return p
 }
 
@@ -477,6 +477,27 @@ func testSet(qs graph.QuadStore) []test {
 				vSmart,
 			},
 		},
+		{
+			message: "use order descending",
+			path:    StartPath(qs).OrderDescending(),
+			expect: []quad.Value{
+				vStatus,
+				vSmartGraph,
+				vPredicate,
+				vGreg,
+				vFred,
+				vFollows,
+				vEmily,
+				vDani,
+				vCharlie,
+				vBob,
+				vAre,
+				vAlice,
+				vSmart,
+				vCool,
+			},
+			unsorted: true,
+		},
 		{
 			message: "use order tags",
 			path:    StartPath(qs).Tag("target").Order(),
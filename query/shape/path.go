@@ -109,6 +109,17 @@ func Predicates(from Shape, in bool) Shape {
 	}}
 }
 
+// PredicatesTo returns the predicates of the quads linking the nodes of from to the nodes of to.
+func PredicatesTo(from, to Shape) Shape {
+	return Unique{NodesFrom{
+		Quads: Quads{
+			{Dir: quad.Subject, Values: from},
+			{Dir: quad.Object, Values: to},
+		},
+		Dir: quad.Predicate,
+	}}
+}
+
 func SavePredicates(from Shape, in bool, tag string) Shape {
 	preds := Save{
 		From: AllNodes{},
@@ -1437,7 +1437,10 @@ func FilterQuads(subject, predicate, object, label []quad.Value) Shape {
 }
 
 type Sort struct {
-	From Shape
+	From       Shape
+	Descending bool
+	// Less overrides the default ordering by the string representation of values, if set.
+	Less iterator.Less
 }
 
 func (s Sort) BuildIterator(qs graph.QuadStore) iterator.Shape {
@@ -1445,6 +1448,12 @@ func (s Sort) BuildIterator(qs graph.QuadStore) iterator.Shape {
 		return iterator.NewNull()
 	}
 	it := s.From.BuildIterator(qs)
+	if s.Less != nil {
+		return iterator.NewSortWith(qs, it, s.Less, s.Descending)
+	}
+	if s.Descending {
+		return iterator.NewSortDescending(qs, it)
+	}
 	return iterator.NewSort(qs, it)
 }
 func (s Sort) Optimize(ctx context.Context, r Optimizer) (Shape, bool) {